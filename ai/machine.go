@@ -0,0 +1,260 @@
+package ai
+
+import (
+	"math"
+	"math/rand/v2"
+)
+
+// Passable reports whether tile (tx,ty) can be walked on.
+type Passable = func(tx, ty int) bool
+
+// Agent is what a Machine drives. enemies.Base implements it, so any enemy
+// type embedding Base can reuse the machine and its built-in states.
+type Agent interface {
+	Pos() (x, y float64)
+	Home() (x, y float64)
+	HPFrac() float64 // current HP / max HP, 0..1
+
+	// Chase path-finds toward (x,y); false means no path exists.
+	Chase(dt, x, y float64, passable Passable) bool
+	// Nudge moves directly by (dx,dy) pixels, sliding along walls.
+	Nudge(dx, dy float64, passable Passable)
+	Speed() float64 // px per second
+}
+
+// StateID names a state. Built-in ids are below; custom states pick their own.
+type StateID string
+
+const (
+	Idle       StateID = "idle"
+	Wander     StateID = "wander"
+	Patrol     StateID = "patrol"
+	Chase      StateID = "chase"
+	Attack     StateID = "attack"
+	Flee       StateID = "flee"
+	ReturnHome StateID = "return_home"
+)
+
+// State is one behavior. Enter runs on the frame the machine switches to it.
+type State interface {
+	Enter(c *Context)
+	Update(c *Context)
+}
+
+// Transition switches to To when When is true and the current state is in
+// From (empty From = any state other than To). The first match wins.
+type Transition struct {
+	From []StateID
+	To   StateID
+	When func(c *Context) bool
+}
+
+// Point is a pixel position (used for patrol routes).
+type Point struct{ X, Y float64 }
+
+// Params tune the built-in states and transitions.
+type Params struct {
+	Rest StateID // state to settle in when nothing is happening (Idle, Wander or Patrol)
+
+	AggroRadius float64 // start chasing within this distance (px)
+	LeashRadius float64 // give up when the target or home is farther than this (px); 0 = 2*AggroRadius
+	AttackRange float64 // stop and attack within this distance (px)
+	FleeHPFrac  float64 // run away below this fraction of HP; 0 = never
+
+	Memory float64 // seconds the last known target position is kept after losing sight; 0 = 3
+
+	WanderRadius float64 // px around home
+	WanderPause  float64 // seconds to wait between wander legs
+	Patrol       []Point // patrol route (pixels)
+}
+
+// Target is where the agent's quarry is and whether the agent can see it.
+type Target struct {
+	X, Y    float64
+	Visible bool // in line of sight this tick
+}
+
+// Context is what states and transitions see each tick.
+type Context struct {
+	Agent    Agent
+	Passable Passable
+	Params   *Params
+	Rand     *rand.Rand // deterministic per agent
+
+	DT               float64
+	TargetX, TargetY float64 // last known target position (live while Visible)
+	Visible          bool    // target in sight right now
+	SinceSeen        float64 // seconds since the target was last visible
+	Dist             float64 // agent → last known target position
+	HomeDist         float64 // agent → home
+	TimeInState      float64
+	NoPath           bool // set by states when the target can't be reached
+
+	// Scratch space states may use (cleared on every state change)
+	GoalX, GoalY float64
+	Timer        float64
+	Index        int
+}
+
+// Machine is a small finite-state machine for one agent.
+type Machine struct {
+	Params Params
+
+	states map[StateID]State
+	trans  []Transition
+	cur    StateID
+	ctx    Context
+	seeded bool
+	seen   bool // target has been seen at least once
+}
+
+// New returns a machine with all built-in states and the standard
+// transitions for p. Use Set / AddTransition to customise it.
+func New(p Params) *Machine {
+	if p.Rest == "" {
+		p.Rest = Idle
+	}
+	if p.LeashRadius <= 0 {
+		p.LeashRadius = 2 * p.AggroRadius
+	}
+	if p.Memory <= 0 {
+		p.Memory = 3
+	}
+	m := &Machine{
+		Params: p,
+		states: map[StateID]State{
+			Idle:       idleState{},
+			Wander:     wanderState{},
+			Patrol:     patrolState{},
+			Chase:      chaseState{},
+			Attack:     attackState{},
+			Flee:       fleeState{},
+			ReturnHome: returnHomeState{},
+		},
+		cur: p.Rest,
+	}
+	m.trans = Standard(&m.Params)
+	return m
+}
+
+// Set adds or replaces the behavior for a state id.
+func (m *Machine) Set(id StateID, s State) { m.states[id] = s }
+
+// AddTransition puts t ahead of the existing transitions, so custom rules
+// override the standard ones.
+func (m *Machine) AddTransition(t Transition) {
+	m.trans = append([]Transition{t}, m.trans...)
+}
+
+// State returns the current state id (handy for debugging / UI).
+func (m *Machine) State() StateID { return m.cur }
+
+// Remembered reports whether the agent still knows where the target is:
+// it's visible, or was seen within Params.Memory seconds.
+func (c *Context) Remembered() bool {
+	return c.Visible || c.SinceSeen < c.Params.Memory
+}
+
+// Update evaluates transitions, then runs the current state once.
+// While the target is out of sight, states work from its last known position.
+func (m *Machine) Update(a Agent, dt float64, t Target, passable Passable) {
+	c := &m.ctx
+	if !m.seeded {
+		hx, hy := a.Home()
+		c.Rand = rand.New(rand.NewPCG(math.Float64bits(hx), math.Float64bits(hy)))
+		m.seeded = true
+		m.enter(a, m.cur)
+	}
+
+	ax, ay := a.Pos()
+	hx, hy := a.Home()
+	c.Agent, c.Passable, c.Params = a, passable, &m.Params
+	c.DT = dt
+	c.Visible = t.Visible
+	switch {
+	case t.Visible:
+		c.TargetX, c.TargetY = t.X, t.Y
+		c.SinceSeen = 0
+		m.seen = true
+	case m.seen:
+		c.SinceSeen += dt
+	default:
+		c.SinceSeen = math.Inf(1) // never seen: nothing to remember
+	}
+	c.Dist = math.Hypot(c.TargetX-ax, c.TargetY-ay)
+	c.HomeDist = math.Hypot(hx-ax, hy-ay)
+	c.TimeInState += dt
+
+	for _, t := range m.trans {
+		if t.To == m.cur || !from(t.From, m.cur) || !t.When(c) {
+			continue
+		}
+		m.enter(a, t.To)
+		break
+	}
+
+	if s, ok := m.states[m.cur]; ok {
+		s.Update(c)
+	}
+}
+
+func (m *Machine) enter(a Agent, id StateID) {
+	m.cur = id
+	c := &m.ctx
+	c.Agent = a
+	c.Params = &m.Params
+	c.TimeInState, c.NoPath = 0, false
+	c.GoalX, c.GoalY, c.Timer, c.Index = 0, 0, 0, 0
+	if s, ok := m.states[id]; ok {
+		s.Enter(c)
+	}
+}
+
+func from(list []StateID, cur StateID) bool {
+	if len(list) == 0 {
+		return true
+	}
+	for _, id := range list {
+		if id == cur {
+			return true
+		}
+	}
+	return false
+}
+
+// Standard returns the default transition table:
+//
+//	any        → flee        when HP drops below FleeHPFrac and the threat is known
+//	rest/home  → chase       when the target is seen inside AggroRadius
+//	chase      → attack      when seen inside AttackRange
+//	attack     → chase       when the target steps out of range or sight
+//	chase      → return_home when there is no path, the leash is exceeded,
+//	                         or the target has been out of sight for Memory seconds
+//	flee       → return_home when far enough away or the threat is forgotten
+//	return_home→ rest        when back home
+func Standard(p *Params) []Transition {
+	calm := []StateID{Idle, Wander, Patrol, ReturnHome}
+	return []Transition{
+		{To: Flee, When: func(c *Context) bool {
+			return p.FleeHPFrac > 0 && c.Agent.HPFrac() < p.FleeHPFrac && c.Remembered()
+		}},
+		{From: []StateID{Attack}, To: Chase, When: func(c *Context) bool {
+			return !c.Visible || c.Dist > p.AttackRange*1.25
+		}},
+		{From: []StateID{Chase}, To: Attack, When: func(c *Context) bool {
+			return c.Visible && c.Dist <= p.AttackRange
+		}},
+		{From: []StateID{Chase}, To: ReturnHome, When: func(c *Context) bool {
+			return c.NoPath || !c.Remembered() || c.Dist > p.LeashRadius || c.HomeDist > p.LeashRadius
+		}},
+		{From: calm, To: Chase, When: func(c *Context) bool {
+			return c.Visible && c.Dist < p.AggroRadius && (c.HomeDist < p.LeashRadius || c.Dist < p.AttackRange*2)
+		}},
+		{From: []StateID{Flee}, To: ReturnHome, When: func(c *Context) bool {
+			return !c.Remembered() || c.Dist > p.AggroRadius*1.5
+		}},
+		{From: []StateID{ReturnHome}, To: p.Rest, When: func(c *Context) bool {
+			return c.HomeDist < 4 || c.NoPath
+		}},
+	}
+}
@@ -0,0 +1,97 @@
+package ai
+
+import "math"
+
+// idleState stands still.
+type idleState struct{}
+
+func (idleState) Enter(c *Context)  {}
+func (idleState) Update(c *Context) {}
+
+// wanderState walks to random points near home, pausing between legs.
+type wanderState struct{}
+
+func (wanderState) Enter(c *Context) { pickWanderGoal(c) }
+
+func (wanderState) Update(c *Context) {
+	if c.Timer > 0 { // pausing
+		c.Timer -= c.DT
+		if c.Timer <= 0 {
+			pickWanderGoal(c)
+		}
+		return
+	}
+	x, y := c.Agent.Pos()
+	if math.Hypot(c.GoalX-x, c.GoalY-y) < 2 || !c.Agent.Chase(c.DT, c.GoalX, c.GoalY, c.Passable) {
+		c.Timer = c.Params.WanderPause * (0.5 + c.Rand.Float64())
+	}
+}
+
+func pickWanderGoal(c *Context) {
+	hx, hy := c.Agent.Home()
+	r := c.Params.WanderRadius * c.Rand.Float64()
+	ang := 2 * math.Pi * c.Rand.Float64()
+	c.GoalX, c.GoalY = hx+r*math.Cos(ang), hy+r*math.Sin(ang)
+}
+
+// patrolState walks the route in Params.Patrol, looping.
+type patrolState struct{}
+
+func (patrolState) Enter(c *Context) {}
+
+func (patrolState) Update(c *Context) {
+	route := c.Params.Patrol
+	if len(route) == 0 {
+		return
+	}
+	p := route[c.Index%len(route)]
+	x, y := c.Agent.Pos()
+	if math.Hypot(p.X-x, p.Y-y) < 2 || !c.Agent.Chase(c.DT, p.X, p.Y, c.Passable) {
+		c.Index++
+	}
+}
+
+// chaseState path-finds toward the target; sets NoPath if it can't.
+type chaseState struct{}
+
+func (chaseState) Enter(c *Context) {}
+
+func (chaseState) Update(c *Context) {
+	if !c.Agent.Chase(c.DT, c.TargetX, c.TargetY, c.Passable) {
+		c.NoPath = true
+	}
+}
+
+// attackState holds position; the game applies the hit via AttackIfInRange.
+type attackState struct{}
+
+func (attackState) Enter(c *Context)  {}
+func (attackState) Update(c *Context) {}
+
+// fleeState runs directly away from the target.
+type fleeState struct{}
+
+func (fleeState) Enter(c *Context) {}
+
+func (fleeState) Update(c *Context) {
+	x, y := c.Agent.Pos()
+	dx, dy := x-c.TargetX, y-c.TargetY
+	d := math.Hypot(dx, dy)
+	if d < 1e-6 {
+		dx, dy, d = 1, 0, 1
+	}
+	step := c.Agent.Speed() * c.DT
+	c.Agent.Nudge(dx/d*step, dy/d*step, c.Passable)
+}
+
+// returnHomeState path-finds back to the spawn point.
+type returnHomeState struct{}
+
+func (returnHomeState) Enter(c *Context) {}
+
+func (returnHomeState) Update(c *Context) {
+	hx, hy := c.Agent.Home()
+	if !c.Agent.Chase(c.DT, hx, hy, c.Passable) {
+		c.NoPath = true
+	}
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/text"
+
+	"example.com/go-quest/rpg"
+)
+
+// spendKeys map 1..6 to the attributes in rpg.AttrIDs order.
+var spendKeys = []ebiten.Key{
+	ebiten.Key1, ebiten.Key2, ebiten.Key3, ebiten.Key4, ebiten.Key5, ebiten.Key6,
+}
+
+// readSpend returns the attribute picked on the character screen this
+// frame, or rpg.AttrNone.
+func (g *Game) readSpend() rpg.AttrID {
+	if !g.charOpen {
+		return rpg.AttrNone
+	}
+	for i, k := range spendKeys {
+		if inpututil.IsKeyJustPressed(k) {
+			return rpg.AttrIDs[i]
+		}
+	}
+	return rpg.AttrNone
+}
+
+// === UI: Character screen (centered, toggled with C) ===
+func (g *Game) drawCharacterScreen(screen *ebiten.Image) {
+	p := g.Player
+	if !g.charOpen || p == nil || g.uiFace == nil {
+		return
+	}
+
+	const panelW, panelH = 240, 190
+	x := (ViewW - panelW) / 2
+	y := (ViewH - panelH) / 2
+
+	bg := ebiten.NewImage(panelW, panelH)
+	bg.Fill(color.NRGBA{0, 0, 0, 200})
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Translate(float64(x), float64(y))
+	screen.DrawImage(bg, op)
+
+	white := color.White
+	gray := color.NRGBA{180, 180, 200, 255}
+	gold := color.NRGBA{255, 215, 0, 255}
+	tx, ty := x+12, y+18
+
+	text.Draw(screen, "CHARACTER", g.uiFace, tx, ty, white)
+	ty += 18
+	text.Draw(screen, fmt.Sprintf("Level %d   XP %d/%d", p.Attr.Level, p.Attr.XP, rpg.XPToNext(p.Attr.Level)), g.uiFace, tx, ty, white)
+	ty += 14
+	pts := gray
+	if p.Attr.Unspent > 0 {
+		pts = gold
+	}
+	text.Draw(screen, fmt.Sprintf("Unspent points: %d", p.Attr.Unspent), g.uiFace, tx, ty, pts)
+	ty += 20
+
+	for i, id := range rpg.AttrIDs {
+		text.Draw(screen, fmt.Sprintf("[%d]  %s  %2d", i+1, id, p.Attr.Get(id)), g.uiFace, tx, ty, white)
+		ty += 14
+	}
+	ty += 6
+	text.Draw(screen, "1-6  Spend point  |  C  Close", g.uiFace, tx, ty, gray)
+}
@@ -1,17 +1,26 @@
 package main
 
 import (
+	"fmt"
 	"image/color"
 	"math"
 
 	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+
+	"example.com/go-quest/enemies"
+	"example.com/go-quest/items"
+	"example.com/go-quest/move"
+	"example.com/go-quest/projectile"
+	"example.com/go-quest/world"
 )
 
 // Draw renders the world, the player, enemies, items, and UI.
 // This was previously in main.go; moving it to its own file keeps main.go smaller.
 func (g *Game) Draw(screen *ebiten.Image) {
-	// clear background
-	screen.Fill(color.NRGBA{18, 18, 24, 255})
+	// clear background (black, so unexplored tiles simply aren't drawn)
+	screen.Fill(color.NRGBA{0, 0, 0, 255})
 
 	// Determine visible tile range based on pixel camera.
 	startTX := int(g.CamXpx) / TileSize
@@ -34,10 +43,11 @@ func (g *Game) Draw(screen *ebiten.Image) {
 	// Draw tiles.
 	for ty := startTY; ty < endTY; ty++ {
 		for tx := startTX; tx < endTX; tx++ {
-			t := g.at(tx, ty)
-			if t == TEmpty {
+			t := g.At(tx, ty)
+			if t == world.TEmpty || !g.IsExplored(tx, ty) {
 				continue
 			}
+			seen := g.IsVisible(tx, ty)
 
 			// World position (in pixels) of this tile.
 			wx := float64(tx * TileSize)
@@ -46,10 +56,14 @@ func (g *Game) Draw(screen *ebiten.Image) {
 			// Screen position = world - camera
 			op := &ebiten.DrawImageOptions{}
 			op.GeoM.Translate(wx-g.CamXpx, wy-g.CamYpx)
+			if !seen {
+				// explored but out of sight: remembered, drawn dim
+				op.ColorM.Scale(0.35, 0.35, 0.45, 1)
+			}
 
 			// Choose tile graphic.
 			switch t {
-			case TFloor:
+			case world.TFloor:
 				if img, ok := g.Atlas.Get("floor"); ok && img != nil {
 					screen.DrawImage(img, op)
 				} else {
@@ -57,7 +71,7 @@ func (g *Game) Draw(screen *ebiten.Image) {
 					img.Fill(color.NRGBA{45, 45, 55, 255})
 					screen.DrawImage(img, op)
 				}
-			case TWall:
+			case world.TWall:
 				if img, ok := g.Atlas.Get("wall"); ok && img != nil {
 					screen.DrawImage(img, op)
 				} else {
@@ -65,7 +79,7 @@ func (g *Game) Draw(screen *ebiten.Image) {
 					img.Fill(color.NRGBA{80, 80, 90, 255})
 					screen.DrawImage(img, op)
 				}
-			case TDoor:
+			case world.TDoor:
 				if img, ok := g.Atlas.Get("door"); ok && img != nil {
 					screen.DrawImage(img, op)
 				} else {
@@ -73,11 +87,26 @@ func (g *Game) Draw(screen *ebiten.Image) {
 					img.Fill(color.NRGBA{180, 140, 60, 255})
 					screen.DrawImage(img, op)
 				}
-			case TWater:
+			case world.TStairsDown, world.TStairsUp:
+				key, fallback := "stairs.down", color.NRGBA{110, 70, 40, 255}
+				if t == world.TStairsUp {
+					key, fallback = "stairs.up", color.NRGBA{170, 150, 110, 255}
+				}
+				if img, ok := g.Atlas.Get(key); ok && img != nil {
+					screen.DrawImage(img, op)
+				} else {
+					img := ebiten.NewImage(TileSize, TileSize)
+					img.Fill(fallback)
+					screen.DrawImage(img, op)
+				}
+			case world.TWater:
 				// Ensure we actually have the water image
 				if img, ok := g.Atlas.Get("water"); ok && img != nil {
 					// Base pass (at the tile’s screen position)
 					screen.DrawImage(img, op)
+					if !seen {
+						break // no shimmer in the fog
+					}
 
 					// Stronger, visible shimmer overlay (no shaders)
 					dx := math.Sin(g.time*2.6) * 1.6
@@ -103,6 +132,9 @@ func (g *Game) Draw(screen *ebiten.Image) {
 	// Draw items on ground (if you have ItemsOnGround and icons)
 	if g.ItemsOnGround != nil {
 		for _, it := range g.ItemsOnGround {
+			if !g.IsVisible(it.X, it.Y) {
+				continue
+			}
 			ix := float64(it.X*TileSize) - g.CamXpx
 			iy := float64(it.Y*TileSize) - g.CamYpx
 
@@ -123,8 +155,8 @@ func (g *Game) Draw(screen *ebiten.Image) {
 			}
 
 			// --- NORMAL ITEMS ---
-			if it.Inst != nil && it.Inst.Icon() != nil {
-				screen.DrawImage(it.Inst.Icon(), op)
+			if img := g.icon(it.Inst); img != nil {
+				screen.DrawImage(img, op)
 				continue
 			}
 
@@ -135,6 +167,7 @@ func (g *Game) Draw(screen *ebiten.Image) {
 		}
 	}
 
+	g.drawNests(screen)
 
 	// Player
 	{
@@ -152,55 +185,77 @@ func (g *Game) Draw(screen *ebiten.Image) {
 		}
 	}
 
-
-	// Draw enemies 
-	// Assume g.Enemies []enemies.Enemy with Draw(screen, camX, camY) method
+	// Draw enemies
+	// Assume g.Enemies []enemies.Enemy; each describes its looks with Sprite()
 	if g.Enemies != nil {
-	for _, e := range g.Enemies {
-		// draw the enemy normally
-		e.Draw(screen, g.CamXpx, g.CamYpx)
-
-		// draw floating HP bar above enemy
-		stats := e.Stats()
-		hp := stats.HP
-		hpMax := float64(stats.HPMax)
-
-		if hpMax > 0 {
-			pct := hp / hpMax
-			if pct < 0 { pct = 0 }
-			if pct > 1 { pct = 1 }
-
-			// world position → screen coords
-			sx := e.X() - g.CamXpx
-			sy := e.Y() - g.CamYpx
-
-			// bar size & placement
-			barW := 28
-			barH := 4
-			bx := sx - float64(barW)/2                   // center horizontally
-			by := sy - float64(TileSize)/2 - 2          // above the head
-
-			// background (dark bar)
-			bg := ebiten.NewImage(barW, barH)
-			bg.Fill(color.NRGBA{40, 40, 40, 200})
-			opbg := &ebiten.DrawImageOptions{}
-			opbg.GeoM.Translate(bx, by)
-			screen.DrawImage(bg, opbg)
-
-			// foreground (red health)
-			fw := int(float64(barW) * pct)
-			if fw > 0 {
-				fg := ebiten.NewImage(fw, barH)
-				fg.Fill(color.NRGBA{200, 40, 40, 255})
-				opfg := &ebiten.DrawImageOptions{}
-				opfg.GeoM.Translate(bx, by)
-				screen.DrawImage(fg, opfg)
+		for _, e := range g.Enemies {
+			// only what the player can see right now
+			if etx, ety := move.Tile(e.X(), e.Y(), TileSize); !g.IsVisible(etx, ety) {
+				continue
+			}
+
+			// draw the enemy normally
+			g.drawEnemy(screen, e)
+			if _, ok := e.(enemies.Boss); ok {
+				continue // bosses get the big bar instead (drawBossBar)
+			}
+
+			// draw floating HP bar above enemy
+			stats := e.Stats()
+			hp := stats.HP
+			hpMax := float64(stats.HPMax)
+
+			if hpMax > 0 {
+				pct := hp / hpMax
+				if pct < 0 {
+					pct = 0
+				}
+				if pct > 1 {
+					pct = 1
+				}
+
+				// world position → screen coords
+				sx := e.X() - g.CamXpx
+				sy := e.Y() - g.CamYpx
+
+				// bar size & placement
+				barW := 28
+				barH := 4
+				bx := sx - float64(barW)/2         // center horizontally
+				by := sy - float64(TileSize)/2 - 2 // above the head
+
+				// background (dark bar)
+				bg := ebiten.NewImage(barW, barH)
+				bg.Fill(color.NRGBA{40, 40, 40, 200})
+				opbg := &ebiten.DrawImageOptions{}
+				opbg.GeoM.Translate(bx, by)
+				screen.DrawImage(bg, opbg)
+
+				// foreground (red health)
+				fw := int(float64(barW) * pct)
+				if fw > 0 {
+					fg := ebiten.NewImage(fw, barH)
+					fg.Fill(color.NRGBA{200, 40, 40, 255})
+					opfg := &ebiten.DrawImageOptions{}
+					opfg.GeoM.Translate(bx, by)
+					screen.DrawImage(fg, opfg)
+				}
+
+				// elites show their name ("Fast Vampiric Goblin") over the bar
+				if len(enemies.Elite(e)) > 0 && g.uiFace != nil {
+					name := e.Name()
+					text.Draw(screen, name, g.uiFace, int(sx)-len(name)*3, int(by)-3, color.NRGBA{255, 200, 90, 255})
+				}
 			}
 		}
-	}
 
 	}
 
+	g.drawProjectiles(screen)
+
+	// === UI: boss health bar (top-center, only during an arena fight) ===
+	g.drawBossBar(screen)
+	g.drawWave(screen)
 
 	// === UI: Player stats panel (top-right corner) ===
 	g.drawStatsPanel(screen)
@@ -209,4 +264,122 @@ func (g *Game) Draw(screen *ebiten.Image) {
 	g.drawInventory(screen)
 	g.drawInventoryHelp(screen)
 	g.drawTooltip(screen)
+	g.drawCharacterScreen(screen)
+}
+
+// icon looks up an item's sprite in the atlas (nil if it has none).
+func (g *Game) icon(it items.Item) *ebiten.Image {
+	if it == nil {
+		return nil
+	}
+	img, _ := g.Atlas.Get(it.Icon())
+	return img
+}
+
+// drawEnemy draws e's sprite: tinted, scaled around its body and flashing
+// red when just hit, or a red square when its icon isn't in the atlas.
+func (g *Game) drawEnemy(screen *ebiten.Image, e enemies.Enemy) {
+	s := e.Sprite()
+	img, ok := g.Atlas.Get(s.Icon)
+	if !ok || img == nil {
+		// fallback square
+		fb := ebiten.NewImage(28, 28)
+		fb.Fill(color.NRGBA{200, 80, 80, 255})
+		op := &ebiten.DrawImageOptions{}
+		op.GeoM.Translate(e.X()-g.CamXpx-14+16, e.Y()-g.CamYpx-14+16) // center on tile pixel
+		screen.DrawImage(fb, op)
+		return
+	}
+	op := &ebiten.DrawImageOptions{}
+	if s.Scale > 0 && s.Scale != 1 {
+		// grow around the sprite's center, which is where the body is
+		half := float64(TileSize) / 2
+		op.GeoM.Translate(-half, -half)
+		op.GeoM.Scale(s.Scale, s.Scale)
+		op.GeoM.Translate(half, half)
+	}
+	op.GeoM.Translate(e.X()-g.CamXpx, e.Y()-g.CamYpx)
+	if s.Tint != [3]float64{} {
+		op.ColorM.Scale(s.Tint[0], s.Tint[1], s.Tint[2], 1)
+	}
+	if s.Flash {
+		op.ColorM.Scale(1.5, 0.5, 0.5, 1.0) // red tint
+	}
+	screen.DrawImage(img, op)
+}
+
+// drawProjectiles draws the shots in flight that the player can see: the
+// shot's atlas icon centered on it, or a colored dot when there is none.
+func (g *Game) drawProjectiles(screen *ebiten.Image) {
+	for _, p := range g.Projectiles {
+		if !g.IsVisible(int(p.X)/TileSize, int(p.Y)/TileSize) {
+			continue
+		}
+		sx, sy := p.X-g.CamXpx, p.Y-g.CamYpx
+		if img, ok := g.Atlas.Get(p.Icon); ok && img != nil {
+			w, h := img.Bounds().Dx(), img.Bounds().Dy()
+			op := &ebiten.DrawImageOptions{}
+			op.GeoM.Translate(-float64(w)/2, -float64(h)/2)
+			op.GeoM.Rotate(math.Atan2(p.VY, p.VX))
+			op.GeoM.Translate(sx, sy)
+			screen.DrawImage(img, op)
+			continue
+		}
+		clr := color.NRGBA{140, 230, 60, 255} // enemy acid
+		if p.Owner == projectile.Player {
+			clr = color.NRGBA{240, 240, 200, 255}
+		}
+		vector.FillCircle(screen, float32(sx), float32(sy), float32(math.Max(p.Radius, 2)), clr, true)
+	}
+}
+
+// drawBossBar shows the name and health of the boss the player is sealed
+// in with, across the top of the screen.
+func (g *Game) drawBossBar(screen *ebiten.Image) {
+	b := g.BossFight()
+	if b == nil {
+		return
+	}
+	const barW, barH = 360, 10
+	x := float64(ViewW-barW) / 2
+	y := 28.0
+
+	pct := 0.0
+	if s := b.Stats(); s.HPMax > 0 {
+		pct = math.Max(0, math.Min(1, s.HP/float64(s.HPMax)))
+	}
+	vector.FillRect(screen, float32(x-2), float32(y-2), barW+4, barH+4, color.NRGBA{0, 0, 0, 200}, false)
+	vector.FillRect(screen, float32(x), float32(y), barW, barH, color.NRGBA{60, 20, 20, 255}, false)
+	vector.FillRect(screen, float32(x), float32(y), float32(barW*pct), barH, color.NRGBA{200, 40, 40, 255}, false)
+
+	if g.uiFace != nil {
+		name := b.Name()
+		text.Draw(screen, name, g.uiFace, ViewW/2-len(name)*3, int(y)-6, color.NRGBA{255, 220, 160, 255})
+	}
+}
+
+// drawNests draws each visible enemy nest as a dark, slowly pulsing blob.
+func (g *Game) drawNests(screen *ebiten.Image) {
+	for _, n := range g.Nests {
+		if !g.IsVisible(n.X, n.Y) {
+			continue
+		}
+		cx := float64(n.X*TileSize+TileSize/2) - g.CamXpx
+		cy := float64(n.Y*TileSize+TileSize/2) - g.CamYpx
+		r := 11 + 1.5*math.Sin(g.time*2.2)
+		vector.FillCircle(screen, float32(cx), float32(cy), float32(r+2), color.NRGBA{30, 10, 30, 255}, true)
+		vector.FillCircle(screen, float32(cx), float32(cy), float32(r), color.NRGBA{90, 40, 90, 255}, true)
+	}
+}
+
+// drawWave shows the wave counter in arena mode.
+func (g *Game) drawWave(screen *ebiten.Image) {
+	if g.Waves == nil || g.uiFace == nil {
+		return
+	}
+	msg := fmt.Sprintf("Wave %d", g.Waves.Wave)
+	if g.Waves.Wave == 0 {
+		msg = "Get ready..."
+	}
+	text.Draw(screen, msg, g.uiFace, 12, 20, color.NRGBA{255, 220, 160, 255})
 }
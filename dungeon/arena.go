@@ -0,0 +1,84 @@
+package dungeon
+
+import (
+	"image"
+	"math/rand/v2"
+)
+
+// Arena is a room set aside for a boss fight. The game seals its
+// entrances while the boss lives and records them in Gates, so they can be
+// reopened (also after a save and load) once the boss is dead.
+type Arena struct {
+	Room  int           `json:"room"`
+	Gates []image.Point `json:"gates,omitempty"` // sealed entrances; empty = open
+}
+
+// Sealed reports whether the arena's entrances are currently shut.
+func (a *Arena) Sealed() bool { return len(a.Gates) > 0 }
+
+// BossArena is a generator hook: it runs Gen, then makes one room a boss
+// arena — the exit room if it's big enough, otherwise the biggest-enough
+// room farthest from the start. Layout.Arena stays nil when no room fits.
+type BossArena struct {
+	Gen     Generator
+	MinSize int // smallest room edge (tiles) worth fighting in; 0 = 6
+}
+
+// Generate builds the floor with Gen and picks its arena.
+func (g BossArena) Generate(W, H int, floorID, wallID int, rng *rand.Rand) *Layout {
+	l := g.Gen.Generate(W, H, floorID, wallID, rng)
+	minSize := g.MinSize
+	if minSize <= 0 {
+		minSize = 6
+	}
+	if l.Start < 0 {
+		return l
+	}
+
+	fits := func(i int) bool {
+		r := l.Rooms[i]
+		return i != l.Start && r.Dx() >= minSize && r.Dy() >= minSize
+	}
+	room := -1
+	if fits(l.Exit) {
+		room = l.Exit
+	} else {
+		dist, order := l.hops(l.Start)
+		for _, i := range order {
+			if fits(i) && (room < 0 || dist[i] >= dist[room]) {
+				room = i
+			}
+		}
+	}
+	if room >= 0 {
+		l.Arena = &Arena{Room: room}
+	}
+	return l
+}
+
+// Entrances lists the walkable tiles just outside room i's rectangle that
+// lead straight into walkable tiles inside it: the openings to shut to
+// trap someone in the room. Call it on the final tiles (after doors,
+// water and bridges), since those can add or remove openings.
+func (l *Layout) Entrances(i int, walkable func(t int) bool) []image.Point {
+	r := l.Rooms[i]
+	inside := func(p image.Point) bool {
+		return p.In(r) && walkable(l.Tiles[idx(l.W, p.X, p.Y)])
+	}
+	var out []image.Point
+	for y := r.Min.Y - 1; y <= r.Max.Y; y++ {
+		for x := r.Min.X - 1; x <= r.Max.X; x++ {
+			p := image.Pt(x, y)
+			if p.In(r) || x < 0 || y < 0 || x >= l.W || y >= l.H || !walkable(l.Tiles[idx(l.W, x, y)]) {
+				continue
+			}
+			for _, d := range dirs4 {
+				if inside(p.Add(d)) {
+					out = append(out, p)
+					break
+				}
+			}
+		}
+	}
+	return out
+}
@@ -0,0 +1,70 @@
+package dungeon
+
+import (
+	"image"
+	"math/rand/v2"
+)
+
+// BSP recursively splits the map into leaves, puts one room in each leaf,
+// and joins sibling subtrees so every room is reachable.
+type BSP struct {
+	MinLeaf int // smallest leaf edge in tiles; 0 = 10, never below 5
+}
+
+// Generate partitions the map and carves one room per leaf.
+func (g BSP) Generate(W, H int, floorID, wallID int, rng *rand.Rand) *Layout {
+	minLeaf := g.MinLeaf
+	if minLeaf <= 0 {
+		minLeaf = 10
+	}
+	minLeaf = max(minLeaf, 5) // leaves need room for a 3x3 room plus margin
+	l := newLayout(W, H, floorID, wallID)
+	g.split(l, image.Rect(1, 1, W-1, H-1), minLeaf, floorID, rng)
+	l.pickStartExit()
+	return l
+}
+
+// split carves rooms inside r and returns the room indices it created.
+func (g BSP) split(l *Layout, r image.Rectangle, minLeaf, floorID int, rng *rand.Rand) []int {
+	canH := r.Dy() >= 2*minLeaf // split into top/bottom
+	canV := r.Dx() >= 2*minLeaf // split into left/right
+	if !canH && !canV {
+		return []int{g.leafRoom(l, r, floorID, rng)}
+	}
+
+	// Prefer cutting across the long side so leaves stay roughly square.
+	vertical := canV && (!canH || r.Dx() > r.Dy() || (r.Dx() == r.Dy() && rng.IntN(2) == 0))
+
+	var a, b image.Rectangle
+	if vertical {
+		cut := r.Min.X + minLeaf + rng.IntN(r.Dx()-2*minLeaf+1)
+		a = image.Rect(r.Min.X, r.Min.Y, cut, r.Max.Y)
+		b = image.Rect(cut, r.Min.Y, r.Max.X, r.Max.Y)
+	} else {
+		cut := r.Min.Y + minLeaf + rng.IntN(r.Dy()-2*minLeaf+1)
+		a = image.Rect(r.Min.X, r.Min.Y, r.Max.X, cut)
+		b = image.Rect(r.Min.X, cut, r.Max.X, r.Max.Y)
+	}
+
+	left := g.split(l, a, minLeaf, floorID, rng)
+	right := g.split(l, b, minLeaf, floorID, rng)
+
+	// Join a random room from each half.
+	l.connect(left[rng.IntN(len(left))], right[rng.IntN(len(right))], floorID)
+	return append(left, right...)
+}
+
+// leafRoom carves a random room that fits inside leaf r with a 1-tile margin.
+func (g BSP) leafRoom(l *Layout, r image.Rectangle, floorID int, rng *rand.Rand) int {
+	maxW, maxH := r.Dx()-2, r.Dy()-2
+	w := max(3, maxW/2+rng.IntN(maxW/2+1))
+	h := max(3, maxH/2+rng.IntN(maxH/2+1))
+	w, h = min(w, maxW), min(h, maxH)
+	x := r.Min.X + 1 + rng.IntN(maxW-w+1)
+	y := r.Min.Y + 1 + rng.IntN(maxH-h+1)
+	room := image.Rect(x, y, x+w, y+h)
+
+	carveRoom(l.Tiles, l.W, room, floorID)
+	l.addRoom(room)
+	return len(l.Rooms) - 1
+}
@@ -0,0 +1,174 @@
+package dungeon
+
+import (
+	"image"
+	"math/rand/v2"
+)
+
+// Caves grows organic caverns with a cellular automaton (the 4-5 rule).
+// Only the largest open region is kept, so the whole cave is connected.
+// Rooms are the floor bounding boxes of each Sector x Sector chunk.
+type Caves struct {
+	WallChance float64 // initial wall density; 0 = 0.45
+	Steps      int     // smoothing passes; 0 = 5
+	Sector     int     // room chunk size in tiles; 0 = 16
+}
+
+// Generate fills the map with noise, smooths it into caves and tags rooms.
+func (g Caves) Generate(W, H int, floorID, wallID int, rng *rand.Rand) *Layout {
+	chance, steps, sector := g.WallChance, g.Steps, g.Sector
+	if chance <= 0 {
+		chance = 0.45
+	}
+	if steps <= 0 {
+		steps = 5
+	}
+	if sector <= 0 {
+		sector = 16
+	}
+
+	l := newLayout(W, H, floorID, wallID)
+
+	// 1) random noise (border stays wall)
+	wall := make([]bool, W*H)
+	for y := 0; y < H; y++ {
+		for x := 0; x < W; x++ {
+			wall[idx(W, x, y)] = x == 0 || y == 0 || x == W-1 || y == H-1 || rng.Float64() < chance
+		}
+	}
+
+	// 2) smoothing: a cell becomes wall with >=5 wall neighbours, floor with <=3
+	next := make([]bool, W*H)
+	for s := 0; s < steps; s++ {
+		for y := 0; y < H; y++ {
+			for x := 0; x < W; x++ {
+				i := idx(W, x, y)
+				if x == 0 || y == 0 || x == W-1 || y == H-1 {
+					next[i] = true
+					continue
+				}
+				n := 0
+				for dy := -1; dy <= 1; dy++ {
+					for dx := -1; dx <= 1; dx++ {
+						if (dx != 0 || dy != 0) && wall[idx(W, x+dx, y+dy)] {
+							n++
+						}
+					}
+				}
+				switch {
+				case n >= 5:
+					next[i] = true
+				case n <= 3:
+					next[i] = false
+				default:
+					next[i] = wall[i]
+				}
+			}
+		}
+		wall, next = next, wall
+	}
+
+	// 3) keep only the largest open region
+	for i, w := range wall {
+		if !w {
+			l.Tiles[i] = floorID
+		}
+	}
+	regions := Regions(l.Tiles, W, H, func(t int) bool { return t == floorID })
+	biggest := -1
+	for i, r := range regions {
+		if biggest < 0 || len(r) > len(regions[biggest]) {
+			biggest = i
+		}
+	}
+	for i, r := range regions {
+		if i == biggest {
+			continue
+		}
+		for _, p := range r {
+			l.Tiles[idx(W, p.X, p.Y)] = wallID
+		}
+	}
+
+	g.tagRooms(l, sector)
+	l.pickStartExit()
+	return l
+}
+
+// tagRooms turns each sector with enough floor into a room (its floor
+// bounding box) and links rooms in neighbouring sectors.
+func (g Caves) tagRooms(l *Layout, sector int) {
+	cols := (l.W + sector - 1) / sector
+	rows := (l.H + sector - 1) / sector
+	roomOf := make([]int, cols*rows)
+
+	for sy := 0; sy < rows; sy++ {
+		for sx := 0; sx < cols; sx++ {
+			roomOf[sy*cols+sx] = -1
+			var box image.Rectangle
+			n := 0
+			for y := sy * sector; y < min((sy+1)*sector, l.H); y++ {
+				for x := sx * sector; x < min((sx+1)*sector, l.W); x++ {
+					if l.Tiles[idx(l.W, x, y)] != l.Floor {
+						continue
+					}
+					box = box.Union(image.Rect(x, y, x+1, y+1))
+					n++
+				}
+			}
+			if n >= sector { // ignore slivers
+				l.addRoom(box)
+				roomOf[sy*cols+sx] = len(l.Rooms) - 1
+			}
+		}
+	}
+
+	link := func(a, b int) {
+		if a < 0 || b < 0 {
+			return
+		}
+		l.Adj[a] = append(l.Adj[a], b)
+		l.Adj[b] = append(l.Adj[b], a)
+	}
+	for sy := 0; sy < rows; sy++ {
+		for sx := 0; sx < cols; sx++ {
+			r := roomOf[sy*cols+sx]
+			if sx+1 < cols {
+				link(r, roomOf[sy*cols+sx+1])
+			}
+			if sy+1 < rows {
+				link(r, roomOf[(sy+1)*cols+sx])
+			}
+		}
+	}
+}
+
+// Regions groups tiles accepted by open into 4-connected regions.
+func Regions(tiles []int, W, H int, open func(t int) bool) [][]image.Point {
+	seen := make([]bool, W*H)
+	var out [][]image.Point
+	for start := range tiles {
+		if seen[start] || !open(tiles[start]) {
+			continue
+		}
+		seen[start] = true
+		region := []image.Point{{start % W, start / W}}
+		for q := 0; q < len(region); q++ {
+			p := region[q]
+			for _, d := range dirs4 {
+				n := p.Add(d)
+				if n.X < 0 || n.Y < 0 || n.X >= W || n.Y >= H {
+					continue
+				}
+				i := idx(W, n.X, n.Y)
+				if seen[i] || !open(tiles[i]) {
+					continue
+				}
+				seen[i] = true
+				region = append(region, n)
+			}
+		}
+		out = append(out, region)
+	}
+	return out
+}
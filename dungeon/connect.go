@@ -0,0 +1,105 @@
+package dungeon
+
+import "image"
+
+// EnsureConnected makes every walkable tile reachable from (sx,sy).
+// It flood-fills from the start, then repeatedly carves the shortest
+// bridge (setting blocked tiles to l.Floor) from the reached area to the
+// nearest cut-off tile. Run it on the final tile set, after doors, water
+// and other decoration have been painted. Returns the number of bridges.
+func (l *Layout) EnsureConnected(sx, sy int, walkable func(t int) bool) int {
+	if sx <= 0 || sy <= 0 || sx >= l.W-1 || sy >= l.H-1 {
+		return 0
+	}
+	if !walkable(l.Tiles[idx(l.W, sx, sy)]) {
+		l.Tiles[idx(l.W, sx, sy)] = l.Floor
+	}
+
+	bridges := 0
+	for {
+		reached := l.Reachable(sx, sy, walkable)
+		if !l.bridge(reached, walkable) {
+			return bridges
+		}
+		bridges++
+	}
+}
+
+// Reachable flood-fills walkable tiles from (sx,sy) and returns a W*H mask.
+func (l *Layout) Reachable(sx, sy int, walkable func(t int) bool) []bool {
+	seen := make([]bool, l.W*l.H)
+	if sx < 0 || sy < 0 || sx >= l.W || sy >= l.H || !walkable(l.Tiles[idx(l.W, sx, sy)]) {
+		return seen
+	}
+	seen[idx(l.W, sx, sy)] = true
+	queue := []image.Point{{sx, sy}}
+	for len(queue) > 0 {
+		p := queue[0]
+		queue = queue[1:]
+		for _, d := range dirs4 {
+			n := p.Add(d)
+			if !l.interior(n) {
+				continue
+			}
+			i := idx(l.W, n.X, n.Y)
+			if seen[i] || !walkable(l.Tiles[i]) {
+				continue
+			}
+			seen[i] = true
+			queue = append(queue, n)
+		}
+	}
+	return seen
+}
+
+// bridge searches outward from every reached tile (through anything but the
+// map border) to the nearest walkable tile that was not reached, and carves
+// the path. Returns false when nothing is cut off.
+func (l *Layout) bridge(reached []bool, walkable func(t int) bool) bool {
+	prev := make([]int, l.W*l.H)
+	for i := range prev {
+		prev[i] = -1
+	}
+	queue := make([]image.Point, 0, l.W*l.H)
+	for i, ok := range reached {
+		if ok {
+			prev[i] = i
+			queue = append(queue, image.Pt(i%l.W, i/l.W))
+		}
+	}
+	if len(queue) == 0 {
+		return false
+	}
+
+	for q := 0; q < len(queue); q++ {
+		p := queue[q]
+		for _, d := range dirs4 {
+			n := p.Add(d)
+			if !l.interior(n) {
+				continue
+			}
+			i := idx(l.W, n.X, n.Y)
+			if prev[i] >= 0 {
+				continue
+			}
+			prev[i] = idx(l.W, p.X, p.Y)
+			if walkable(l.Tiles[i]) {
+				// Found a cut-off tile: walk back and carve.
+				for j := prev[i]; !reached[j]; j = prev[j] {
+					if !walkable(l.Tiles[j]) {
+						l.Tiles[j] = l.Floor
+					}
+				}
+				return true
+			}
+			queue = append(queue, n)
+		}
+	}
+	return false
+}
+
+func (l *Layout) interior(p image.Point) bool {
+	return p.X > 0 && p.Y > 0 && p.X < l.W-1 && p.Y < l.H-1
+}
+
+var dirs4 = [4]image.Point{{1, 0}, {-1, 0}, {0, 1}, {0, -1}}
@@ -0,0 +1,61 @@
+package dungeon
+
+import (
+	"fmt"
+	"testing"
+)
+
+const (
+	testFloor = 1
+	testWall  = 2
+	testWater = 3 // blocks like a wall, but EnsureConnected may carve it
+)
+
+func TestEnsureConnected(t *testing.T) {
+	gens := []struct {
+		name string
+		gen  Generator
+	}{
+		{"rooms", RoomsCorridors{}},
+		{"bsp", BSP{}},
+		{"caves", Caves{}},
+	}
+	walkable := func(t int) bool { return t == testFloor }
+
+	for _, g := range gens {
+		for seed := uint64(1); seed <= 8; seed++ {
+			t.Run(fmt.Sprintf("%s/seed%d", g.name, seed), func(t *testing.T) {
+				rng := NewRand(seed)
+				l := g.gen.Generate(80, 60, testFloor, testWall, rng)
+
+				// flood a few random bands so some regions get sealed off
+				for i := 0; i < 6; i++ {
+					if rng.IntN(2) == 0 {
+						x := 1 + rng.IntN(l.W-2)
+						for y := 1; y < l.H-1; y++ {
+							l.Tiles[idx(l.W, x, y)] = testWater
+						}
+					} else {
+						y := 1 + rng.IntN(l.H-2)
+						for x := 1; x < l.W-1; x++ {
+							l.Tiles[idx(l.W, x, y)] = testWater
+						}
+					}
+				}
+
+				sx, sy := l.RoomCenter(l.Start)
+				l.EnsureConnected(sx, sy, walkable)
+
+				reached := l.Reachable(sx, sy, walkable)
+				for i, tile := range l.Tiles {
+					if walkable(tile) && !reached[i] {
+						t.Fatalf("floor tile (%d,%d) unreachable from start (%d,%d)", i%l.W, i/l.W, sx, sy)
+					}
+				}
+				if n := l.EnsureConnected(sx, sy, walkable); n != 0 {
+					t.Fatalf("second pass carved %d bridge(s), want 0", n)
+				}
+			})
+		}
+	}
+}
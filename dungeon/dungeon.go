@@ -5,43 +5,158 @@ import (
 	"math/rand/v2"
 )
 
-// Generate returns a W*H tile slice filled with walls and carved floors.
+// NewSource returns the PCG source behind NewRand. Keep it if you need to
+// save and restore the RNG state (it implements encoding.BinaryMarshaler).
+func NewSource(seed uint64) *rand.PCG {
+	return rand.NewPCG(seed, seed^0x9e3779b97f4a7c15)
+}
+
+// NewRand returns a deterministic RNG for the given seed.
+// The same seed always yields the same sequence, so a whole run can be replayed.
+func NewRand(seed uint64) *rand.Rand {
+	return rand.New(NewSource(seed))
+}
+
+// Corridor is one straight run of carved floor. L-shaped connections are
+// stored as two segments that share the corner tile.
+type Corridor struct {
+	From, To int         // indices into Layout.Rooms
+	A, B     image.Point // inclusive endpoints (tile coords)
+}
+
+// Layout is everything the generator knows about a map, not just the tiles.
+// Spawning, loot and quests can work per room instead of rescanning Tiles.
+type Layout struct {
+	W, H  int
+	Tiles []int // len = W*H
+
+	Floor, Wall int // tile ids the generator was given
+
+	Rooms     []image.Rectangle
+	Corridors []Corridor
+	Adj       [][]int // Adj[i] lists rooms directly joined to room i
+
+	Start, Exit int // room indices; -1 if the map has no rooms
+
+	Arena *Arena `json:",omitempty"` // boss room, if the floor has one (see BossArena)
+}
+
+// Generator builds one floor. Implementations differ in shape (rooms,
+// partitions, caves) but all return a Layout and draw only from rng.
+type Generator interface {
+	Generate(W, H int, floorID, wallID int, rng *rand.Rand) *Layout
+}
+
+// Generate builds a rooms+corridors Layout (the classic generator).
 // floorID and wallID come from your game's tile constants (e.g., TFloor/TWall).
-func Generate(W, H int, floorID, wallID int) []int {
-	tiles := make([]int, W*H)
+// All randomness is drawn from rng, so the same seed gives bit-identical tiles.
+func Generate(W, H int, floorID, wallID int, rng *rand.Rand) *Layout {
+	return RoomsCorridors{}.Generate(W, H, floorID, wallID, rng)
+}
 
+// RoomCenter returns the floor tile closest to the center of room i.
+// Rectangular rooms are solid floor, so this is just the center; cave
+// "rooms" are bounding boxes and may have walls in the middle.
+func (l *Layout) RoomCenter(i int) (int, int) {
+	cx, cy := center(l.Rooms[i])
+	if l.Tiles[idx(l.W, cx, cy)] == l.Floor {
+		return cx, cy
+	}
+	r := l.Rooms[i]
+	bx, by, best := cx, cy, -1
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			if l.Tiles[idx(l.W, x, y)] != l.Floor {
+				continue
+			}
+			d := (x-cx)*(x-cx) + (y-cy)*(y-cy)
+			if best < 0 || d < best {
+				bx, by, best = x, y, d
+			}
+		}
+	}
+	return bx, by
+}
+
+// RoomAt returns the index of the room containing tile (x,y), or -1.
+func (l *Layout) RoomAt(x, y int) int {
+	p := image.Pt(x, y)
+	for i, r := range l.Rooms {
+		if p.In(r) {
+			return i
+		}
+	}
+	return -1
+}
+
+func newLayout(W, H, floorID, wallID int) *Layout {
+	l := &Layout{
+		W: W, H: H, Tiles: make([]int, W*H),
+		Floor: floorID, Wall: wallID,
+		Start: -1, Exit: -1,
+	}
 	// Start fully walled.
-	for i := range tiles {
-		tiles[i] = wallID
+	for i := range l.Tiles {
+		l.Tiles[i] = wallID
 	}
+	return l
+}
 
-	rooms := make([]image.Rectangle, 0, 32)
-	const maxRooms = 24
+func (l *Layout) addRoom(r image.Rectangle) {
+	l.Rooms = append(l.Rooms, r)
+	l.Adj = append(l.Adj, nil)
+}
 
-	for r := 0; r < maxRooms; r++ {
-		w := 4 + rand.IntN(8) // room width: 4..11 tiles
-		h := 4 + rand.IntN(8) // room height: 4..11 tiles
-		x := 1 + rand.IntN(W-w-2)
-		y := 1 + rand.IntN(H-h-2)
-		room := image.Rect(x, y, x+w, y+h)
+// connect carves an L-shaped corridor between the centers of rooms a and b
+// and records both segments plus the adjacency edge.
+func (l *Layout) connect(a, b, floorID int) {
+	pcx, pcy := center(l.Rooms[a])
+	cx, cy := center(l.Rooms[b])
+	// Horizontal then vertical (simple and readable).
+	carveH(l.Tiles, l.W, min(pcx, cx), max(pcx, cx), pcy, floorID)
+	carveV(l.Tiles, l.W, min(pcy, cy), max(pcy, cy), cx, floorID)
+	l.Corridors = append(l.Corridors,
+		Corridor{From: a, To: b, A: image.Pt(pcx, pcy), B: image.Pt(cx, pcy)},
+		Corridor{From: a, To: b, A: image.Pt(cx, pcy), B: image.Pt(cx, cy)},
+	)
+	l.Adj[a] = append(l.Adj[a], b)
+	l.Adj[b] = append(l.Adj[b], a)
+}
 
-		if overlaps(room, rooms) {
-			continue // skip if it overlaps (with padding)
+// pickStartExit uses room 0 as the start and the room the most hops away
+// (through the adjacency graph) as the exit.
+func (l *Layout) pickStartExit() {
+	if len(l.Rooms) == 0 {
+		return
+	}
+	l.Start, l.Exit = 0, 0
+	dist, order := l.hops(0)
+	for _, r := range order {
+		if dist[r] > dist[l.Exit] {
+			l.Exit = r
 		}
+	}
+}
 
-		carveRoom(tiles, W, room, floorID)
-		if len(rooms) > 0 {
-			// Connect to previous room center with an L-shaped corridor.
-			pcx, pcy := center(rooms[len(rooms)-1])
-			cx, cy := center(room)
-			// Horizontal then vertical (simple and readable).
-			carveH(tiles, W, min(pcx, cx), max(pcx, cx), pcy, floorID)
-			carveV(tiles, W, min(pcy, cy), max(pcy, cy), cx, floorID)
+// hops returns how many corridors separate each room from room `from`
+// (-1 for rooms it can't reach), plus the reachable rooms in the order a
+// breadth-first search visits them.
+func (l *Layout) hops(from int) (dist, order []int) {
+	dist = make([]int, len(l.Rooms))
+	for i := range dist {
+		dist[i] = -1
+	}
+	dist[from] = 0
+	order = []int{from}
+	for i := 0; i < len(order); i++ {
+		for _, n := range l.Adj[order[i]] {
+			if dist[n] < 0 {
+				dist[n] = dist[order[i]] + 1
+				order = append(order, n)
+			}
 		}
-		rooms = append(rooms, room)
 	}
-
-	return tiles
+	return dist, order
 }
 
 /* ---------- helpers (private) ---------- */
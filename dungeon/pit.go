@@ -0,0 +1,42 @@
+package dungeon
+
+import (
+	"image"
+	"math/rand/v2"
+)
+
+// Pit is a single open room in the middle of the map with four pillars
+// to dodge around: an arena for wave fights. It has no corridors, so the
+// room is both start and exit.
+type Pit struct {
+	RoomW, RoomH int // room size in tiles; 0 = 26x18, never below 10x8
+}
+
+// Generate carves the pit. It draws nothing from rng.
+func (g Pit) Generate(W, H int, floorID, wallID int, rng *rand.Rand) *Layout {
+	rw, rh := g.RoomW, g.RoomH
+	if rw <= 0 {
+		rw = 26
+	}
+	if rh <= 0 {
+		rh = 18
+	}
+	rw, rh = min(max(rw, 10), W-2), min(max(rh, 8), H-2)
+
+	l := newLayout(W, H, floorID, wallID)
+	x, y := (W-rw)/2, (H-rh)/2
+	room := image.Rect(x, y, x+rw, y+rh)
+	carveRoom(l.Tiles, W, room, floorID)
+	l.addRoom(room)
+
+	// 2x2 pillars a quarter of the way in from each corner
+	for _, p := range []image.Point{
+		{x + rw/4, y + rh/4}, {x + 3*rw/4 - 1, y + rh/4},
+		{x + rw/4, y + 3*rh/4 - 1}, {x + 3*rw/4 - 1, y + 3*rh/4 - 1},
+	} {
+		carveRoom(l.Tiles, W, image.Rect(p.X, p.Y, p.X+2, p.Y+2), wallID)
+	}
+
+	l.Start, l.Exit = 0, 0
+	return l
+}
@@ -0,0 +1,42 @@
+package dungeon
+
+import (
+	"image"
+	"math/rand/v2"
+)
+
+// RoomsCorridors places random rooms and links them with L-shaped corridors.
+type RoomsCorridors struct {
+	MaxRooms int // placement attempts; 0 = 24
+}
+
+// Generate scatters non-overlapping rooms and joins each to the previous one.
+func (g RoomsCorridors) Generate(W, H int, floorID, wallID int, rng *rand.Rand) *Layout {
+	l := newLayout(W, H, floorID, wallID)
+	maxRooms := g.MaxRooms
+	if maxRooms <= 0 {
+		maxRooms = 24
+	}
+
+	for r := 0; r < maxRooms; r++ {
+		w := 4 + rng.IntN(8) // room width: 4..11 tiles
+		h := 4 + rng.IntN(8) // room height: 4..11 tiles
+		x := 1 + rng.IntN(W-w-2)
+		y := 1 + rng.IntN(H-h-2)
+		room := image.Rect(x, y, x+w, y+h)
+
+		if overlaps(room, l.Rooms) {
+			continue // skip if it overlaps (with padding)
+		}
+
+		carveRoom(l.Tiles, W, room, floorID)
+		l.addRoom(room)
+		if n := len(l.Rooms); n > 1 {
+			// Connect to previous room center with an L-shaped corridor.
+			l.connect(n-2, n-1, floorID)
+		}
+	}
+
+	l.pickStartExit()
+	return l
+}
@@ -1,13 +1,17 @@
 package enemies
 
 import (
-	"image/color"
 	"math"
 
+	"example.com/go-quest/ai"
+	"example.com/go-quest/pathfind"
 	"example.com/go-quest/rpg"
-	"github.com/hajimehoshi/ebiten/v2"
 )
 
+// tileSize matches world.TileSize. Like the player, an enemy's x,y is the
+// top-left pixel of its 32x32 sprite.
+const tileSize = 32
+
 // Base contains common fields for enemies and simple helpers.
 // Individual enemy types embed Base and implement the rest.
 type Base struct {
@@ -17,37 +21,63 @@ type Base struct {
 	x, y float64 // pixel position
 
 	// visuals
-	icon *ebiten.Image
+	icon  string     // atlas key
+	tint  [3]float64 // RGB scale for the sprite (zero = untinted)
+	scale float64    // sprite size, drawn around the tile-sized body (0 = 1)
 
 	// RPG data
-	attr rpg.Attributes
-	stats rpg.Stats
-	mods []rpg.Modifier
+	attr    rpg.Attributes
+	stats   rpg.Stats
+	mods    []rpg.Modifier
+	effects rpg.Effects
+	own     rpg.Stats // stats before mods and effects (see recompute)
+	hasOwn  bool
 
 	// combat
-	hitCooldown    float64 // seconds between enemy attacks
-	hitTimer       float64
-	meleeRangePx   float64
-	attackDamage   float64
-	hitFlash float64
+	hitCooldown  float64 // seconds between enemy attacks
+	hitTimer     float64
+	meleeRangePx float64
+	hitFlash     float64
+	onHit        []rpg.Effect // inflicted on the player by each landed attack
+
+	// rewards
+	xp        int    // experience per level, awarded on death
+	lootTable string // loot table rolled on death (see package loot)
+
+	// elite affixes (see MakeElite)
+	elite       []EliteAffix
+	summons     int     // minions called in so far (Summoner)
+	summonTimer float64 // until the next summon
 
 	// movement
 	moveSpeed float64 // px per second
+	ownSpeed  float64 // moveSpeed before mods and effects
+	path      pathfind.Cache
+	homeX     float64 // where the enemy was placed; AI returns here
+	homeY     float64
+
+	// AI (nil = the type drives itself in Update)
+	brain *ai.Machine
 
 	alive bool
 	time  float64
-
 }
 
-func (b *Base) ID() string            { return b.id }
-func (b *Base) Name() string          { return b.name }
-func (b *Base) X() float64            { return b.x }
-func (b *Base) Y() float64            { return b.y }
-func (b *Base) SetPos(x, y float64)   { b.x = x; b.y = y }
-func (b *Base) Stats() rpg.Stats      { return b.stats }
+func (b *Base) ID() string           { return b.id }
+func (b *Base) Name() string         { return b.name }
+func (b *Base) X() float64           { return b.x }
+func (b *Base) Y() float64           { return b.y }
+func (b *Base) SetPos(x, y float64)  { b.x, b.y = x, y; b.homeX, b.homeY = x, y }
+func (b *Base) Stats() rpg.Stats     { return b.stats }
 func (b *Base) Attr() rpg.Attributes { return b.attr }
 func (b *Base) IsAlive() bool        { return b.alive }
 
+// LootTable names the loot table the enemy drops from ("" = nothing).
+func (b *Base) LootTable() string { return b.lootTable }
+
+// XP is the experience the enemy is worth: its per-level XP times its level.
+func (b *Base) XP() int { return b.xp * max(b.attr.Level, 1) }
+
 func (b *Base) TakeDamage(amount float64) {
 	b.hitFlash = 0.15 // 150ms red flash
 	b.stats.HP -= amount
@@ -57,6 +87,94 @@ func (b *Base) TakeDamage(amount float64) {
 	}
 }
 
+// SetHP sets current HP (clamped at 0); an enemy at 0 HP is dead.
+func (b *Base) SetHP(hp float64) {
+	b.stats.HP = hp
+	if b.stats.HP <= 0 {
+		b.stats.HP = 0
+		b.alive = false
+	}
+}
+
+// ScaleLevel raises the enemy by n levels: +1 to every attribute and
+// +15% HP, max HP and Attack per level. The type's own stats are scaled in
+// place (other stats grow by what the attributes add), so values a type
+// sets by hand survive. Stats are recomputed.
+func (b *Base) ScaleLevel(n int) {
+	if n <= 0 {
+		return
+	}
+	b.snapshotOwn()
+	f := 1 + 0.15*float64(n)
+	hp := b.stats.HP * f
+	hpMax := int(float64(b.own.HPMax) * f)
+	atk := int(float64(b.own.Attack)*f + 0.5)
+	before := rpg.Baseline(b.attr)
+	b.attr.Level += n
+	b.attr.Str += n
+	b.attr.Dex += n
+	b.attr.Int += n
+	b.attr.Vit += n
+	b.attr.Wis += n
+	b.attr.Lck += n
+	grow(&b.own, before, rpg.Baseline(b.attr))
+	b.own.HPMax = hpMax
+	b.own.Attack = atk
+	b.stats.HP = hp
+	b.recompute()
+}
+
+// grow adds the difference between two baselines to s.
+func grow(s *rpg.Stats, from, to rpg.Stats) {
+	s.MPMax += to.MPMax - from.MPMax
+	s.StaminaMax += to.StaminaMax - from.StaminaMax
+	s.Magic += to.Magic - from.Magic
+	s.Defense += to.Defense - from.Defense
+	s.Resist += to.Resist - from.Resist
+	s.CritChance += to.CritChance - from.CritChance
+	s.CritMult += to.CritMult - from.CritMult
+	s.MoveSpeed += to.MoveSpeed - from.MoveSpeed
+}
+
+// HitEffects are the status effects the enemy's attacks inflict.
+func (b *Base) HitEffects() []rpg.Effect { return b.onHit }
+
+// AddEffect applies a timed status effect to the enemy.
+func (b *Base) AddEffect(e rpg.Effect) {
+	if b.effects.Apply(e) {
+		b.recompute()
+	}
+}
+
+// snapshotOwn remembers the type's own stats (as set up by its
+// constructor) the first time mods or effects are layered on top.
+func (b *Base) snapshotOwn() {
+	if !b.hasOwn {
+		b.own, b.ownSpeed, b.hasOwn = b.stats, b.moveSpeed, true
+	}
+}
+
+// recompute rebuilds stats from the own stats plus mods and active effects,
+// keeping current HP/MP/stamina within the new caps.
+func (b *Base) recompute() {
+	b.snapshotOwn()
+	s := b.own
+	for _, m := range b.mods {
+		m.Apply(&s)
+	}
+	for _, m := range b.effects.Mods() {
+		m.Apply(&s)
+	}
+	s.HP = min(b.stats.HP, float64(s.HPMax))
+	s.MP = min(b.stats.MP, float64(s.MPMax))
+	s.Stamina = min(b.stats.Stamina, float64(s.StaminaMax))
+	b.stats = s
+	// moveSpeed is the enemy's own pace, so speed mods scale it by ratio
+	if b.own.MoveSpeed > 0 {
+		b.moveSpeed = b.ownSpeed * s.MoveSpeed / b.own.MoveSpeed
+	}
+}
+
 // simple cooldown-driven AttackIfInRange; returns true if attack occurred
 func (b *Base) AttackIfInRange(px, py float64) bool {
 	if !b.alive {
@@ -77,8 +195,15 @@ func (b *Base) AttackIfInRange(px, py float64) bool {
 	return false
 }
 
-func (b *Base) tick(dt float64) {
+func (b *Base) tick(dt float64, env *Env) {
 	b.time += dt
+	b.eliteTick(dt, env)
+	if b.effects.Update(dt, &b.stats) {
+		b.recompute()
+	}
+	if b.stats.HP <= 0 { // poison, burn...
+		b.alive = false
+	}
 	if b.hitTimer > 0 {
 		b.hitTimer -= dt
 		if b.hitTimer < 0 {
@@ -86,30 +211,11 @@ func (b *Base) tick(dt float64) {
 		}
 	}
 	if b.hitFlash > 0 {
-    b.hitFlash -= dt
-}
-}
-
-// simple draw helper
-func (b *Base) drawSelf(screen *ebiten.Image, camX, camY float64) {
-	op := &ebiten.DrawImageOptions{}
-	op.GeoM.Translate(b.x-camX, b.y-camY)
-	if b.icon != nil {
-		screen.DrawImage(b.icon, op)
-		return
+		b.hitFlash -= dt
 	}
-	// fallback square
-	img := ebiten.NewImage(28, 28)
-	img.Fill(colorRGBA(200, 80, 80, 255))
-	op2 := &ebiten.DrawImageOptions{}
-	op2.GeoM.Translate(b.x-camX-14+16, b.y-camY-14+16) // center on tile pixel
-	screen.DrawImage(img, op2)
-
-	if b.hitFlash > 0 {
-    op.ColorM.Scale(1.5, 0.5, 0.5, 1.0) // red tint
-}
 }
 
-func colorRGBA(r, g, b, a uint8) color.NRGBA {
-	return color.NRGBA{r, g, b, a}
+// Sprite returns the enemy's looks: icon, tint, size and hit flash.
+func (b *Base) Sprite() Sprite {
+	return Sprite{Icon: b.icon, Tint: b.tint, Scale: b.scale, Flash: b.hitFlash > 0}
 }
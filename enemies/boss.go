@@ -0,0 +1,192 @@
+package enemies
+
+import (
+	"math"
+
+	"example.com/go-quest/ai"
+	"example.com/go-quest/projectile"
+	"example.com/go-quest/rpg"
+)
+
+// Boss is an enemy that anchors an arena fight: the world seals the arena
+// while it lives and shows its health bar across the top of the screen.
+type Boss interface {
+	Enemy
+	Phase() int // current phase, 0 = the opening one
+}
+
+// Phase is one stage of a boss fight. The boss moves to the next phase
+// when its HP fraction drops to that phase's HPFrac.
+type Phase struct {
+	HPFrac   float64        // enter at or below this HP fraction (ignored for the first phase)
+	Announce string         // shown to the player on entering
+	Mods     []rpg.Modifier // stat changes for the phase (they replace the previous phase's)
+	Summon   string         // enemy id called in on entering ("" = none)
+	Minions  int
+	Patterns []Pattern // special attacks, used in turn
+}
+
+// Pattern is one special attack. Do performs it; the boss then waits
+// Cooldown seconds before the next pattern of its phase.
+type Pattern struct {
+	Name     string
+	Cooldown float64
+	Do       func(b *BossBase, env *Env)
+}
+
+// BossBase is the common part of a boss: Base plus phases, patterns and a
+// charge. A boss type embeds it, fills in Base and phases in its
+// constructor, and registers with RegisterBoss.
+type BossBase struct {
+	Base
+	phases []Phase
+	phase  int
+	next   int     // next pattern of the phase
+	cd     float64 // seconds until the next pattern
+
+	dash         float64 // seconds of charge left
+	dashX, dashY float64 // charge direction (unit vector)
+}
+
+func (b *BossBase) Phase() int { return b.phase }
+
+// Update runs phase changes, any charge in progress, the AI and, once the
+// boss is engaged, its attack patterns.
+func (b *BossBase) Update(dt float64, env *Env) {
+	b.tick(dt, env)
+	if !b.alive {
+		return
+	}
+	for b.phase+1 < len(b.phases) && b.HPFrac() <= b.phases[b.phase+1].HPFrac {
+		b.enterPhase(b.phase+1, env)
+	}
+
+	if b.dash > 0 {
+		b.dash -= dt
+		step := 3 * b.moveSpeed * dt
+		b.Move(b.dashX*step, b.dashY*step, env.Passable)
+		return
+	}
+
+	b.think(dt, env)
+	if s := b.AIState(); s != ai.Chase && s != ai.Attack {
+		return // not in the fight (yet)
+	}
+	b.cd -= dt
+	if b.cd > 0 || len(b.phases) == 0 {
+		return
+	}
+	ps := b.phases[b.phase].Patterns
+	if len(ps) == 0 {
+		return
+	}
+	p := ps[b.next%len(ps)]
+	b.next++
+	p.Do(b, env)
+	b.cd = p.Cooldown
+}
+
+// enterPhase switches to phase i: new mods, a short breather, the
+// announcement and any minions.
+func (b *BossBase) enterPhase(i int, env *Env) {
+	b.setPhase(i)
+	b.cd = 1.0
+	ph := b.phases[i]
+	if ph.Announce != "" && env.Say != nil {
+		env.Say(ph.Announce)
+	}
+	if ph.Summon != "" {
+		b.summon(env, ph.Summon, ph.Minions)
+	}
+}
+
+func (b *BossBase) setPhase(i int) {
+	b.phase, b.next = i, 0
+	b.mods = b.phases[i].Mods
+	b.recompute()
+}
+
+// SetHP restores HP (save/load) and quietly moves to the phase that HP
+// belongs in, without replaying announcements or summons.
+func (b *BossBase) SetHP(hp float64) {
+	b.Base.SetHP(hp)
+	for b.phase+1 < len(b.phases) && b.HPFrac() <= b.phases[b.phase+1].HPFrac {
+		b.setPhase(b.phase + 1)
+	}
+}
+
+// summon calls in n enemies of type id around the boss.
+func (b *BossBase) summon(env *Env, id string, n int) {
+	if env.Summon == nil {
+		return
+	}
+	for i := 0; i < n; i++ {
+		ang := 2 * math.Pi * float64(i) / float64(n)
+		env.Summon(id, b.x+math.Cos(ang)*tileSize*1.5, b.y+math.Sin(ang)*tileSize*1.5)
+	}
+}
+
+// fan fires n shots at the player spread evenly over spread radians
+// (2π = a full ring, aimed or not).
+func (b *BossBase) fan(env *Env, n int, spread, speed float64, kind rpg.DamageKind) {
+	half := float64(tileSize) / 2
+	cx, cy := b.x+half, b.y+half
+	base := math.Atan2(env.PY-b.y, env.PX-b.x)
+	step := 0.0
+	if n > 1 {
+		step = spread / float64(n-1)
+		if spread >= 2*math.Pi {
+			step = spread / float64(n)
+		}
+		base -= step * float64(n-1) / 2
+	}
+	for i := 0; i < n; i++ {
+		shot := projectile.Projectile{Kind: kind, Life: 2.5, Radius: 6}
+		b.shootDir(env, shot, cx, cy, base+step*float64(i), speed)
+	}
+}
+
+// shootDir fires shot from (cx,cy) in direction ang (radians).
+func (b *BossBase) shootDir(env *Env, shot projectile.Projectile, cx, cy, ang, speed float64) {
+	if env.Fire == nil {
+		return
+	}
+	shot.Aim(cx, cy, cx+math.Cos(ang), cy+math.Sin(ang), speed)
+	shot.Owner = projectile.Enemy
+	shot.Attacker = b.stats
+	shot.OnHit = b.DealtDamage
+	env.Fire(shot)
+}
+
+// Volley fires n shots in a fan of spreadDeg degrees at the player.
+func Volley(n int, spreadDeg, cooldown float64) Pattern {
+	return Pattern{Name: "volley", Cooldown: cooldown, Do: func(b *BossBase, env *Env) {
+		b.fan(env, n, spreadDeg*math.Pi/180, 200, rpg.Physical)
+	}}
+}
+
+// Nova fires n magical shots in a ring around the boss.
+func Nova(n int, cooldown float64) Pattern {
+	return Pattern{Name: "nova", Cooldown: cooldown, Do: func(b *BossBase, env *Env) {
+		b.fan(env, n, 2*math.Pi, 150, rpg.Magical)
+	}}
+}
+
+// Charge rushes at the player's current position at triple speed for secs.
+func Charge(secs, cooldown float64) Pattern {
+	return Pattern{Name: "charge", Cooldown: cooldown, Do: func(b *BossBase, env *Env) {
+		dx, dy := env.PX-b.x, env.PY-b.y
+		d := math.Hypot(dx, dy)
+		if d < 1e-6 {
+			return
+		}
+		b.dashX, b.dashY, b.dash = dx/d, dy/d, secs
+	}}
+}
+
+// Summon calls in n enemies of type id around the boss.
+func Summon(id string, n int, cooldown float64) Pattern {
+	return Pattern{Name: "summon", Cooldown: cooldown, Do: func(b *BossBase, env *Env) {
+		b.summon(env, id, n)
+	}}
+}
@@ -0,0 +1,118 @@
+package enemies
+
+import (
+	"math"
+
+	"example.com/go-quest/ai"
+	"example.com/go-quest/move"
+	"example.com/go-quest/vision"
+)
+
+// Base implements ai.Agent, so every enemy type can be driven by an
+// ai.Machine: set b.brain in the constructor and call b.think in Update.
+
+func (b *Base) Pos() (float64, float64)  { return b.x, b.y }
+func (b *Base) Home() (float64, float64) { return b.homeX, b.homeY }
+func (b *Base) Speed() float64           { return b.moveSpeed }
+
+func (b *Base) HPFrac() float64 {
+	if b.stats.HPMax <= 0 {
+		return 1
+	}
+	return b.stats.HP / float64(b.stats.HPMax)
+}
+
+// Nudge moves directly by (dx,dy) with wall sliding (see Move).
+func (b *Base) Nudge(dx, dy float64, passable ai.Passable) { b.Move(dx, dy, passable) }
+
+// think runs the enemy's state machine against the player. The player
+// only counts as seen when inside the leash radius with a clear line of
+// sight; otherwise the machine works from where it last saw them.
+func (b *Base) think(dt float64, env *Env) {
+	if b.brain != nil {
+		b.brain.Update(b, dt, ai.Target{X: env.PX, Y: env.PY, Visible: b.canSee(env)}, env.Passable)
+	}
+}
+
+// canSee reports whether the player is within sight range and not hidden
+// behind opaque tiles.
+func (b *Base) canSee(env *Env) bool {
+	if math.Hypot(env.PX-b.x, env.PY-b.y) > b.brain.Params.LeashRadius {
+		return false
+	}
+	if env.Opaque == nil {
+		return true
+	}
+	ex, ey := move.Tile(b.x, b.y, tileSize)
+	px, py := move.Tile(env.PX, env.PY, tileSize)
+	return vision.LineOfSight(ex, ey, px, py, env.Opaque)
+}
+
+// AIState returns the current AI state id ("" if the enemy has no brain).
+func (b *Base) AIState() ai.StateID {
+	if b.brain == nil {
+		return ""
+	}
+	return b.brain.State()
+}
+
+// Chase walks toward (px,py) along an A* path around walls and water.
+// It returns false, without moving, when no path exists (give up the chase).
+func (b *Base) Chase(dt, px, py float64, passable ai.Passable) bool {
+	return b.chaseAt(b.moveSpeed, dt, px, py, passable)
+}
+
+// chaseAt is Chase at speed px per second instead of the usual moveSpeed.
+// The path cache still ages by dt, so a faster chase doesn't repath sooner.
+func (b *Base) chaseAt(speed, dt, px, py float64, passable ai.Passable) bool {
+	tx, ty := move.Tile(b.x, b.y, tileSize)
+	gx, gy := move.Tile(px, py, tileSize)
+	next, ok := b.path.Next(dt, tx, ty, gx, gy, passable)
+	if !ok {
+		return false
+	}
+	// head for the next tile; on the last step, go straight for the target
+	wx, wy := float64(next.X*tileSize), float64(next.Y*tileSize)
+	if next.X == gx && next.Y == gy {
+		wx, wy = px, py
+	}
+	b.moveToward(wx, wy, speed*dt, passable)
+	return true
+}
+
+// moveToward steps at (wx,wy) by up to step pixels without overshooting,
+// sliding along walls instead of passing through them.
+func (b *Base) moveToward(wx, wy, step float64, passable func(tx, ty int) bool) {
+	dx := wx - b.x
+	dy := wy - b.y
+	dist := math.Hypot(dx, dy)
+	if dist < 1e-6 {
+		return
+	}
+	step = math.Min(step, dist)
+	b.Move(dx/dist*step, dy/dist*step, passable)
+}
+
+// Move displaces the enemy by (dx,dy) pixels with axis-separated sliding
+// collision against passable — the same helper the player moves with.
+func (b *Base) Move(dx, dy float64, passable func(tx, ty int) bool) {
+	b.x, b.y = move.Slide(b.x, b.y, dx, dy, tileSize, passable)
+}
+
+// dashChase replaces the stock chase (data files: "chase": "dash"): close
+// in with short bursts at double speed followed by a brief pause.
+type dashChase struct{ b *Base }
+
+func (dashChase) Enter(c *ai.Context) { c.Timer = 0.4 }
+
+func (d dashChase) Update(c *ai.Context) {
+	c.Timer -= c.DT
+	switch {
+	case c.Timer > 0: // dashing: cover twice the ground this frame
+		if !d.b.chaseAt(2*d.b.moveSpeed, c.DT, c.TargetX, c.TargetY, c.Passable) {
+			c.NoPath = true
+		}
+	case c.Timer < -0.25: // pause over, dash again
+		c.Timer = 0.4
+	}
+}
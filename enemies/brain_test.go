@@ -0,0 +1,39 @@
+package enemies
+
+import (
+	"testing"
+
+	"example.com/go-quest/ai"
+)
+
+// A dash covers twice the ground but must age the path cache in real time:
+// a walled-off target is only searched for again once MaxAge has passed.
+func TestDashChaseAgesPathByDT(t *testing.T) {
+	walled := true
+	passable := func(tx, ty int) bool {
+		return ty == 0 && tx >= 0 && tx < 20 && !(walled && tx == 5)
+	}
+	b := &Base{moveSpeed: 40}
+	b.path.MaxAge = 0.25
+	c := &ai.Context{Agent: b, Passable: passable, DT: 0.0625, TargetX: 10 * tileSize}
+	d := dashChase{b}
+	d.Enter(c)
+
+	d.Update(c)
+	if !c.NoPath || b.x != 0 {
+		t.Fatalf("walled-off target: NoPath %v, x %v; want true, 0", c.NoPath, b.x)
+	}
+
+	// open the wall: the cached "no path" holds until MaxAge (4 ticks)
+	walled = false
+	for tick := 1; tick <= 4; tick++ {
+		c.NoPath = false
+		d.Update(c)
+		if found := !c.NoPath; found != (tick == 4) {
+			t.Fatalf("tick %d after opening: found path = %v", tick, found)
+		}
+	}
+	if want := 2 * b.moveSpeed * c.DT; b.x != want {
+		t.Fatalf("dash moved %v px in one tick, want %v", b.x, want)
+	}
+}
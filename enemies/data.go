@@ -0,0 +1,240 @@
+package enemies
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"example.com/go-quest/ai"
+	"example.com/go-quest/loot"
+	"example.com/go-quest/projectile"
+	"example.com/go-quest/rpg"
+)
+
+// Def is an enemy type defined in a data file rather than in Go. A file
+// holds {"enemies": [Def, ...]}. Attributes, stat overrides and AI params
+// use the Go field names of rpg.Attributes, rpg.Stats and ai.Params,
+// e.g. "attr": {"Level": 1, "Str": 2}, "ai": {"Rest": "wander", "AggroRadius": 128}.
+type Def struct {
+	ID    string     `json:"id"`
+	Name  string     `json:"name"`
+	Icon  string     `json:"icon"`            // atlas key
+	Tint  [3]float64 `json:"tint,omitempty"`  // RGB sprite color scale, e.g. [1.2, 0.8, 0.8]
+	Scale float64    `json:"scale,omitempty"` // sprite size (0 = 1)
+
+	Attr  rpg.Attributes  `json:"attr"`
+	Stats json.RawMessage `json:"stats,omitempty"` // overrides on top of the attributes' stats, e.g. {"HPMax": 20}
+
+	Speed    float64        `json:"speed"`    // px per second
+	Cooldown float64        `json:"cooldown"` // seconds between attacks
+	Range    float64        `json:"range"`    // melee reach (px)
+	OnHit    []HitEffectDef `json:"on_hit,omitempty"`
+	Ranged   *RangedDef     `json:"ranged,omitempty"` // shoots instead of fighting in melee
+
+	AI    ai.Params `json:"ai"`              // AttackRange defaults to Range
+	Chase string    `json:"chase,omitempty"` // "dash" = goblin-style bursts; "" = the stock chase
+
+	Loot string `json:"loot,omitempty"` // loot table id (see package loot)
+	XP   int    `json:"xp"`             // per level
+}
+
+// HitEffectDef is a status effect a landed attack inflicts.
+type HitEffectDef struct {
+	Effect string  `json:"effect"` // "poison" or "burn"
+	DPS    float64 `json:"dps"`
+	Secs   float64 `json:"secs"`
+}
+
+// RangedDef makes an enemy attack with projectiles from up to
+// AI.AttackRange away, like the spitter.
+type RangedDef struct {
+	Speed    float64 `json:"speed"`               // px per second
+	Life     float64 `json:"life"`                // seconds before the shot fizzles
+	Radius   float64 `json:"radius"`              // hit radius (px)
+	Kind     string  `json:"kind,omitempty"`      // "physical" (default) or "magic"
+	Icon     string  `json:"icon,omitempty"`      // atlas key ("" = a plain dot)
+	KeepAway float64 `json:"keep_away,omitempty"` // back off when the player is closer (px)
+}
+
+type defFile struct {
+	Enemies []Def `json:"enemies"`
+}
+
+// LoadDir loads every *.json file in dir (in name order) with LoadFile.
+func LoadDir(dir string) error {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return err
+	}
+	sort.Strings(paths)
+	for _, p := range paths {
+		if err := LoadFile(p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LoadFile reads enemy definitions from a JSON file and registers each one
+// with Register, next to the Go-coded enemies. Defining an ID that's
+// already registered is an error, so data can't silently replace code.
+// The whole file is checked first: if any definition is bad, none are
+// registered.
+func LoadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("enemies: %w", err)
+	}
+	var f defFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return fmt.Errorf("enemies: %s: %w", path, err)
+	}
+	seen := map[string]bool{}
+	for i := range f.Enemies {
+		d := &f.Enemies[i]
+		if err := d.validate(); err != nil {
+			return fmt.Errorf("enemies: %s: %w", path, err)
+		}
+		if _, dup := registry[d.ID]; dup {
+			return fmt.Errorf("enemies: %s: id %q is already registered", path, d.ID)
+		}
+		if seen[d.ID] {
+			return fmt.Errorf("enemies: %s: id %q is defined twice", path, d.ID)
+		}
+		seen[d.ID] = true
+	}
+	for _, d := range f.Enemies {
+		Register(d.ID, d.ctor())
+	}
+	return nil
+}
+
+var hitEffects = map[string]func(dps, secs float64) rpg.Effect{
+	"poison": rpg.Poison,
+	"burn":   rpg.Burn,
+}
+
+var damageKinds = map[string]rpg.DamageKind{
+	"": rpg.Physical, "physical": rpg.Physical, "magic": rpg.Magical,
+}
+
+func (d *Def) validate() error {
+	if d.ID == "" {
+		return fmt.Errorf("enemy without an id")
+	}
+	if d.Name == "" {
+		d.Name = d.ID
+	}
+	if d.Speed <= 0 || d.Cooldown <= 0 {
+		return fmt.Errorf("enemy %q: speed and cooldown must be positive", d.ID)
+	}
+	if _, err := d.stats(); err != nil {
+		return fmt.Errorf("enemy %q: stats: %w", d.ID, err)
+	}
+	for _, h := range d.OnHit {
+		if _, ok := hitEffects[h.Effect]; !ok {
+			return fmt.Errorf("enemy %q: unknown hit effect %q", d.ID, h.Effect)
+		}
+	}
+	if r := d.Ranged; r != nil {
+		if _, ok := damageKinds[r.Kind]; !ok {
+			return fmt.Errorf("enemy %q: unknown damage kind %q", d.ID, r.Kind)
+		}
+		if r.Speed <= 0 || r.Life <= 0 || d.AI.AttackRange <= 0 {
+			return fmt.Errorf("enemy %q: ranged needs a shot speed, life and ai.AttackRange", d.ID)
+		}
+	}
+	switch d.AI.Rest {
+	case "", ai.Idle, ai.Wander:
+	case ai.Patrol:
+		if len(d.AI.Patrol) == 0 {
+			return fmt.Errorf("enemy %q: patrol without a route", d.ID)
+		}
+	default:
+		return fmt.Errorf("enemy %q: unknown rest state %q", d.ID, d.AI.Rest)
+	}
+	if d.Chase != "" && d.Chase != "dash" {
+		return fmt.Errorf("enemy %q: unknown chase %q", d.ID, d.Chase)
+	}
+	if d.Loot != "" && !loot.HasTable(d.Loot) {
+		return fmt.Errorf("enemy %q: unknown loot table %q", d.ID, d.Loot)
+	}
+	return nil
+}
+
+// stats computes the attributes' stats with the overrides on top, at
+// full health. Unknown override fields are an error, so typos show up.
+func (d *Def) stats() (rpg.Stats, error) {
+	s := rpg.Recompute(d.Attr)
+	if len(d.Stats) > 0 {
+		dec := json.NewDecoder(bytes.NewReader(d.Stats))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&s); err != nil {
+			return s, err
+		}
+	}
+	s.HP = float64(s.HPMax)
+	return s, nil
+}
+
+// ctor builds the registry constructor for d.
+func (d Def) ctor() Ctor {
+	return func() Enemy {
+		e := &dataEnemy{def: &d}
+		e.id = d.ID
+		e.name = d.Name
+		e.icon = d.Icon
+		e.tint = d.Tint
+		e.scale = d.Scale
+		e.attr = d.Attr
+		e.stats, _ = d.stats() // checked by validate
+		e.moveSpeed = d.Speed
+		e.hitCooldown = d.Cooldown
+		e.meleeRangePx = d.Range
+		e.xp = d.XP
+		e.lootTable = d.Loot
+		for _, h := range d.OnHit {
+			e.onHit = append(e.onHit, hitEffects[h.Effect](h.DPS, h.Secs))
+		}
+		e.alive = true
+
+		p := d.AI
+		if p.AttackRange <= 0 {
+			p.AttackRange = d.Range
+		}
+		e.brain = ai.New(p)
+		if d.Chase == "dash" {
+			e.brain.Set(ai.Chase, dashChase{&e.Base})
+		}
+		if r := d.Ranged; r != nil && r.KeepAway > 0 {
+			e.brain.Set(ai.Attack, kiteAttack{min: r.KeepAway})
+		}
+		return e
+	}
+}
+
+// dataEnemy is an enemy built from a Def.
+type dataEnemy struct {
+	Base
+	def *Def
+}
+
+func (e *dataEnemy) Update(dt float64, env *Env) {
+	e.tick(dt, env)
+	e.think(dt, env)
+	if r := e.def.Ranged; r != nil {
+		shot := projectile.Projectile{Kind: damageKinds[r.Kind], Life: r.Life, Radius: r.Radius, Icon: r.Icon}
+		e.fireWhenReady(env, shot, r.Speed)
+	}
+}
+
+// AttackIfInRange only lands melee hits for enemies that don't shoot.
+func (e *dataEnemy) AttackIfInRange(px, py float64) bool {
+	if e.def.Ranged != nil {
+		return false
+	}
+	return e.Base.AttackIfInRange(px, py)
+}
@@ -0,0 +1,76 @@
+package enemies
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"example.com/go-quest/rpg"
+)
+
+// writeDefs writes {"enemies": [defs...]} to a temp file and returns its path.
+func writeDefs(t *testing.T, defs ...string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "enemies.json")
+	data := `{"enemies": [` + strings.Join(defs, ",") + `]}`
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// turtle is a valid definition with hand-set stats; id is filled in.
+func turtle(id string) string {
+	return `{"id": "` + id + `", "attr": {"Level": 1, "Vit": 2},
+		"stats": {"HPMax": 20, "Attack": 4, "Defense": 9},
+		"speed": 10, "cooldown": 1, "range": 20}`
+}
+
+func TestLoadFileRejects(t *testing.T) {
+	tests := []struct {
+		name string
+		defs []string
+		want string // in the error
+	}{
+		{
+			"typo in stats",
+			[]string{turtle("t_ok"), `{"id": "t_bad", "stats": {"Defence": 1}, "speed": 10, "cooldown": 1}`},
+			`unknown field "Defence"`,
+		},
+		{
+			"defined twice",
+			[]string{turtle("t_ok"), turtle("t_ok")},
+			`"t_ok" is defined twice`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := LoadFile(writeDefs(t, tt.defs...))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("LoadFile error = %v, want one mentioning %s", err, tt.want)
+			}
+			if New("t_ok") != nil {
+				t.Fatalf("a definition was registered from a bad file")
+			}
+		})
+	}
+}
+
+func TestScaleLevelKeepsOverrides(t *testing.T) {
+	if err := LoadFile(writeDefs(t, turtle("t_turtle"))); err != nil {
+		t.Fatal(err)
+	}
+	e := New("t_turtle")
+	before := rpg.Baseline(e.Attr())
+	e.ScaleLevel(2)
+	after := rpg.Baseline(e.Attr())
+
+	s := e.Stats()
+	if want := 9 + after.Defense - before.Defense; s.Defense != want {
+		t.Errorf("Defense = %v, want the override grown by the attributes: %v", s.Defense, want)
+	}
+	if s.HPMax != 26 || s.Attack != 5 {
+		t.Errorf("HPMax, Attack = %v, %v; want 26, 5 (+30%%)", s.HPMax, s.Attack)
+	}
+}
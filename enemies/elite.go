@@ -0,0 +1,198 @@
+package enemies
+
+import (
+	"math"
+	"math/rand/v2"
+
+	"example.com/go-quest/ai"
+	"example.com/go-quest/projectile"
+	"example.com/go-quest/rpg"
+)
+
+// EliteAffix is a trait a champion enemy is born with: a stat boost, a
+// behavior hook, or both.
+type EliteAffix int
+
+const (
+	Fast      EliteAffix = iota // moves and swings faster
+	Armored                     // tougher hide, more HP
+	Vampiric                    // heals by part of the damage it deals
+	Explosive                   // bursts into flame when it dies
+	Summoner                    // calls in its own kind during a fight
+	numEliteAffixes
+)
+
+func (a EliteAffix) String() string {
+	switch a {
+	case Fast:
+		return "Fast"
+	case Armored:
+		return "Armored"
+	case Vampiric:
+		return "Vampiric"
+	case Explosive:
+		return "Explosive"
+	case Summoner:
+		return "Summoner"
+	}
+	return "?"
+}
+
+// ParseEliteAffix is the inverse of String (used by saves).
+func ParseEliteAffix(s string) (EliteAffix, bool) {
+	for a := EliteAffix(0); a < numEliteAffixes; a++ {
+		if a.String() == s {
+			return a, true
+		}
+	}
+	return 0, false
+}
+
+// Elite tuning.
+const (
+	vampiricLeech  = 0.5  // share of damage dealt healed back
+	blastShots     = 8    // fire shots in an explosive elite's death ring
+	summonEvery    = 8.0  // seconds between a summoner's calls
+	maxSummons     = 3    // minions per summoner, ever
+	eliteHitFaster = 0.75 // Fast: attack cooldown multiplier
+)
+
+// eliteTints color an elite by its first affix.
+var eliteTints = [numEliteAffixes][3]float64{
+	Fast:      {0.8, 1.2, 1.4},
+	Armored:   {1.0, 1.0, 0.7},
+	Vampiric:  {1.4, 0.5, 0.6},
+	Explosive: {1.5, 0.9, 0.4},
+	Summoner:  {1.1, 0.7, 1.4},
+}
+
+// MakeElite turns e into a champion with the given affixes: their
+// modifiers, a tint and a name prefix ("Fast Vampiric Goblin"). Bosses
+// and enemies that don't embed Base can't be elites; it reports whether
+// the affixes were applied. Call it after ScaleLevel.
+func MakeElite(e Enemy, affixes ...EliteAffix) bool {
+	bd, ok := e.(body)
+	if _, boss := e.(Boss); !ok || boss || len(affixes) == 0 {
+		return false
+	}
+	b := bd.base()
+	b.snapshotOwn()
+	prefix := ""
+	for _, a := range affixes {
+		switch a {
+		case Fast:
+			b.mods = append(b.mods, rpg.Mult{SpeedMul: 1.5})
+			b.hitCooldown *= eliteHitFaster
+		case Armored:
+			b.mods = append(b.mods, rpg.Flat{Defense: 4 + b.attr.Level, Resist: 2 + b.attr.Level/2, HPMax: b.own.HPMax / 2})
+		case Vampiric, Explosive:
+			// behavior only (DealtDamage / OnDeath)
+		case Summoner:
+			b.summonTimer = summonEvery / 2
+		default:
+			continue
+		}
+		b.elite = append(b.elite, a)
+		prefix += a.String() + " "
+	}
+	if len(b.elite) == 0 {
+		return false
+	}
+	b.recompute()
+	b.stats.HP = float64(b.stats.HPMax) // born at full (new) health
+	b.name = prefix + b.name
+	t := eliteTints[b.elite[0]]
+	if b.tint != [3]float64{} {
+		t = [3]float64{t[0] * b.tint[0], t[1] * b.tint[1], t[2] * b.tint[2]}
+	}
+	b.tint = t
+	return true
+}
+
+// RollElite makes e an elite with n different random affixes.
+func RollElite(e Enemy, r *rand.Rand, n int) bool {
+	n = min(max(n, 1), int(numEliteAffixes))
+	var affixes []EliteAffix
+	for _, i := range r.Perm(int(numEliteAffixes))[:n] {
+		affixes = append(affixes, EliteAffix(i))
+	}
+	return MakeElite(e, affixes...)
+}
+
+// Elite returns e's elite affixes (nil for a regular enemy).
+func Elite(e Enemy) []EliteAffix {
+	if bd, ok := e.(body); ok {
+		return bd.base().elite
+	}
+	return nil
+}
+
+// Summons is how many minions e has called in as a summoner elite.
+func Summons(e Enemy) int {
+	if bd, ok := e.(body); ok {
+		return bd.base().summons
+	}
+	return 0
+}
+
+// SetSummons restores e's summon count (save/load), so a summoner's
+// lifetime cap of maxSummons holds across a reload.
+func SetSummons(e Enemy, n int) {
+	if bd, ok := e.(body); ok {
+		bd.base().summons = n
+	}
+}
+
+func (b *Base) hasAffix(a EliteAffix) bool {
+	for _, x := range b.elite {
+		if x == a {
+			return true
+		}
+	}
+	return false
+}
+
+// DealtDamage heals a vampiric elite by part of the damage it dealt.
+func (b *Base) DealtDamage(amount float64) {
+	if b.alive && b.hasAffix(Vampiric) && amount > 0 {
+		b.stats.HP = min(b.stats.HP+amount*vampiricLeech, float64(b.stats.HPMax))
+	}
+}
+
+// OnDeath sets off an explosive elite: a ring of burning shots.
+func (b *Base) OnDeath(env *Env) {
+	if !b.hasAffix(Explosive) || env.Fire == nil {
+		return
+	}
+	half := float64(tileSize) / 2
+	cx, cy := b.x+half, b.y+half
+	for i := 0; i < blastShots; i++ {
+		ang := 2 * math.Pi * float64(i) / blastShots
+		shot := projectile.Projectile{
+			Owner: projectile.Enemy, Attacker: b.stats, Kind: rpg.Magical,
+			Effects: []rpg.Effect{rpg.Burn(2, 3)},
+			Life:    0.5, Radius: 8,
+		}
+		shot.Aim(cx, cy, cx+math.Cos(ang), cy+math.Sin(ang), 220)
+		env.Fire(shot)
+	}
+}
+
+// eliteTick runs the timed elite behaviors: a summoner in a fight calls in
+// one more of its own (regular) kind every summonEvery seconds.
+func (b *Base) eliteTick(dt float64, env *Env) {
+	if !b.hasAffix(Summoner) || b.summons >= maxSummons || env.Summon == nil {
+		return
+	}
+	if s := b.AIState(); s != ai.Chase && s != ai.Attack {
+		return
+	}
+	b.summonTimer -= dt
+	if b.summonTimer > 0 {
+		return
+	}
+	b.summonTimer = summonEvery
+	ang := float64(b.summons) * math.Pi / 2 // a different side each time
+	b.summons++
+	env.Summon(b.id, b.x+math.Cos(ang)*tileSize, b.y+math.Sin(ang)*tileSize)
+}
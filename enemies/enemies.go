@@ -1,8 +1,9 @@
 package enemies
 
 import (
-	"github.com/hajimehoshi/ebiten/v2"
-	"example.com/go-quest/atlas"
+	"sort"
+
+	"example.com/go-quest/projectile"
 	"example.com/go-quest/rpg"
 )
 
@@ -16,44 +17,108 @@ type Enemy interface {
 	Y() float64
 	SetPos(x, y float64)
 
-	// Update AI (dt seconds), given a reference to player pos and a passable callback
-	Update(dt float64, px, py float64, passable func(tx, ty int) bool)
+	// Update AI (dt seconds) against the world as described by env
+	Update(dt float64, env *Env)
 
-	// Draw the enemy (screen coords are handled by caller, or provide camera)
-	Draw(screen *ebiten.Image, camX, camY float64)
+	// Sprite says how to draw the enemy; the game does the drawing
+	Sprite() Sprite
 
 	// Combat API
-	TakeDamage(amount float64)            // apply damage to the enemy
-	AttackIfInRange(px, py float64) bool  // returns true if it attacked and did damage (you may want to handle damage externally)
+	TakeDamage(amount float64)           // apply damage to the enemy
+	SetHP(hp float64)                    // restore HP directly (save/load)
+	AttackIfInRange(px, py float64) bool // returns true if it attacked and did damage (you may want to handle damage externally)
+	HitEffects() []rpg.Effect            // status effects a landed attack inflicts (poison...)
+	DealtDamage(amount float64)          // told what each landed attack did (vampiric elites heal)
 
 	// Status
 	IsAlive() bool
+	XP() int           // experience awarded for the kill
+	LootTable() string // loot table rolled when it dies
+
+	// OnDeath runs death effects (an explosive elite's blast...). The world
+	// calls it once, when it removes the dead enemy.
+	OnDeath(env *Env)
+
+	// AddEffect applies a timed status effect (poison, haste, ...)
+	AddEffect(e rpg.Effect)
+
+	// ScaleLevel makes the enemy n levels tougher (used for deeper floors)
+	ScaleLevel(n int)
 
 	// Expose stats/attrs for UI / debug
 	Stats() rpg.Stats
 	Attr() rpg.Attributes
 }
 
+// Sprite describes an enemy's looks for whoever draws it.
+type Sprite struct {
+	Icon  string     // atlas key
+	Tint  [3]float64 // RGB color scale (zero = untinted)
+	Scale float64    // size, around the tile-sized body (0 = 1)
+	Flash bool       // just took a hit: drawn flashing red
+}
+
+// Env is what an enemy can see of the world during Update.
+type Env struct {
+	PX, PY   float64               // player position (pixels, sprite top-left)
+	Passable func(tx, ty int) bool // tile can be walked on
+	Opaque   func(tx, ty int) bool // tile blocks line of sight
+
+	// Fire launches a projectile into the world (nil = ranged attacks
+	// are disabled, e.g. in a headless check).
+	Fire func(p projectile.Projectile)
+
+	// Summon asks the world for a new enemy of type id at (x,y) pixels,
+	// and Say shows the player a message. Either may be nil.
+	Summon func(id string, x, y float64)
+	Say    func(msg string)
+}
+
 // Registry (so enemy files can self-register)
-type Ctor func(atl *atlas.Atlas) Enemy
+type Ctor func() Enemy
 
-var registry = map[string]Ctor{}
+var (
+	registry = map[string]Ctor{}
+	bosses   = map[string]bool{}
+)
 
 func Register(id string, c Ctor) {
 	registry[id] = c
 }
 
-func New(id string, atl *atlas.Atlas) Enemy {
+// RegisterBoss registers a boss type. Bosses are built with New like any
+// other enemy, but AllIDs leaves them out so random spawns never pick one.
+func RegisterBoss(id string, c Ctor) {
+	registry[id] = c
+	bosses[id] = true
+}
+
+func New(id string) Enemy {
 	if c, ok := registry[id]; ok {
-		return c(atl)
+		return c()
 	}
 	return nil
 }
 
+// AllIDs returns the registered ids in sorted order, so seeded picks are
+// stable. Bosses are not included (see BossIDs).
 func AllIDs() []string {
 	out := make([]string, 0, len(registry))
 	for k := range registry {
+		if !bosses[k] {
+			out = append(out, k)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// BossIDs returns the registered boss ids in sorted order.
+func BossIDs() []string {
+	out := make([]string, 0, len(bosses))
+	for k := range bosses {
 		out = append(out, k)
 	}
+	sort.Strings(out)
 	return out
 }
@@ -0,0 +1,56 @@
+package enemies
+
+import (
+	"math"
+
+	"example.com/go-quest/ai"
+	"example.com/go-quest/projectile"
+)
+
+// shoot fires shot from the center of the enemy's sprite at the center of
+// the player's, if the world accepts projectiles. The shot carries the
+// enemy's current stats and hit effects; the caller sets speed, life,
+// radius and looks. It returns false when nothing was fired.
+func (b *Base) shoot(env *Env, shot projectile.Projectile, speed float64) bool {
+	if env.Fire == nil || !b.alive {
+		return false
+	}
+	half := float64(tileSize) / 2
+	shot.Aim(b.x+half, b.y+half, env.PX+half, env.PY+half, speed)
+	shot.Owner = projectile.Enemy
+	shot.Attacker = b.stats
+	shot.OnHit = b.DealtDamage
+	if shot.Effects == nil {
+		shot.Effects = b.onHit
+	}
+	env.Fire(shot)
+	return true
+}
+
+// fireWhenReady shoots shot at the player while the machine is in its
+// Attack state and the attack cooldown is up.
+func (b *Base) fireWhenReady(env *Env, shot projectile.Projectile, speed float64) {
+	if b.AIState() == ai.Attack && b.hitTimer == 0 && b.shoot(env, shot, speed) {
+		b.hitTimer = b.hitCooldown
+	}
+}
+
+// kiteAttack is an Attack state for ranged enemies: hold position while
+// the target is in range, but back away when it comes closer than min.
+type kiteAttack struct{ min float64 }
+
+func (kiteAttack) Enter(c *ai.Context) {}
+
+func (k kiteAttack) Update(c *ai.Context) {
+	if c.Dist >= k.min {
+		return
+	}
+	x, y := c.Agent.Pos()
+	dx, dy := x-c.TargetX, y-c.TargetY
+	d := math.Hypot(dx, dy)
+	if d < 1e-6 {
+		dx, dy, d = 1, 0, 1
+	}
+	step := c.Agent.Speed() * c.DT
+	c.Agent.Nudge(dx/d*step, dy/d*step, c.Passable)
+}
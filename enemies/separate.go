@@ -0,0 +1,68 @@
+package enemies
+
+import "math"
+
+// Separation tuning: enemies closer than separationRadius push each other
+// apart, hardest when fully overlapping. The player is an immovable body
+// with a smaller radius, kept inside melee range so enemies can still hit.
+const (
+	separationRadius = 24.0  // px between enemy sprite origins
+	playerRadius     = 14.0  // px between an enemy and the player
+	separationPush   = 120.0 // px/s at full overlap
+)
+
+// body gives package code access to the shared Base of any enemy.
+type body interface{ base() *Base }
+
+func (b *Base) base() *Base { return b }
+
+// Separate applies a soft separation force so crowds spread around the
+// player instead of merging into one sprite. Call once per step after the
+// enemies have moved; pushes respect walls via Base.Move.
+func Separate(list []Enemy, px, py, dt float64, passable func(tx, ty int) bool) {
+	bases := make([]*Base, 0, len(list))
+	for _, e := range list {
+		if b, ok := e.(body); ok && e.IsAlive() {
+			bases = append(bases, b.base())
+		}
+	}
+
+	pushX := make([]float64, len(bases))
+	pushY := make([]float64, len(bases))
+	for i, a := range bases {
+		// enemy ↔ player
+		fx, fy := repel(a.x, a.y, px, py, playerRadius, i)
+		pushX[i] += fx
+		pushY[i] += fy
+
+		// enemy ↔ enemy (each pair once, equal and opposite)
+		for j := i + 1; j < len(bases); j++ {
+			fx, fy := repel(a.x, a.y, bases[j].x, bases[j].y, separationRadius, i+j)
+			pushX[i] += fx
+			pushY[i] += fy
+			pushX[j] -= fx
+			pushY[j] -= fy
+		}
+	}
+
+	for i, b := range bases {
+		b.Move(pushX[i]*separationPush*dt, pushY[i]*separationPush*dt, passable)
+	}
+}
+
+// repel returns a push for a body at (ax,ay) away from (bx,by), scaled
+// 0..1 by overlap within radius. Exactly stacked bodies get a fixed
+// direction from salt.
+func repel(ax, ay, bx, by, radius float64, salt int) (float64, float64) {
+	dx, dy := ax-bx, ay-by
+	d := math.Hypot(dx, dy)
+	if d >= radius {
+		return 0, 0
+	}
+	if d < 1e-6 {
+		ang := float64(salt) * 2.399963 // golden angle spreads stacked bodies evenly
+		return math.Cos(ang), math.Sin(ang)
+	}
+	f := 1 - d/radius
+	return dx / d * f, dy / d * f
+}
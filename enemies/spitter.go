@@ -0,0 +1,63 @@
+package enemies
+
+import (
+	"example.com/go-quest/ai"
+	"example.com/go-quest/projectile"
+	"example.com/go-quest/rpg"
+)
+
+// Spitter is a ranged slime: it keeps a few tiles away and lobs acid.
+type Spitter struct {
+	Base
+	spitSpeed float64 // px per second
+}
+
+func newSpitter() Enemy {
+	s := &Spitter{}
+	s.id = "spitter"
+	s.name = "Spitter"
+	s.icon = "enemy.spitter"
+	s.tint = [3]float64{0.6, 1.3, 0.5} // sickly green
+	// frail, but its acid is magical (Int drives Magic)
+	s.attr = rpg.Attributes{Level: 1, Str: 1, Dex: 3, Int: 3, Vit: 2, Wis: 1, Lck: 1}
+	s.stats = rpg.Recompute(s.attr)
+	s.stats.HP = float64(14)
+	s.stats.HPMax = 14
+	s.hitCooldown = 1.6
+	s.meleeRangePx = 0 // never bites; see AttackIfInRange
+	s.moveSpeed = 30.0
+	s.spitSpeed = 180.0
+	s.xp = 12
+	s.lootTable = "slime"
+	s.onHit = []rpg.Effect{rpg.Poison(1, 3)}
+	s.alive = true
+
+	// AI: spit from up to 5 tiles away, backing off inside 3 tiles
+	s.brain = ai.New(ai.Params{
+		Rest:         ai.Wander,
+		AggroRadius:  192,
+		AttackRange:  5 * tileSize,
+		WanderRadius: 2 * tileSize,
+		WanderPause:  2,
+	})
+	s.brain.Set(ai.Attack, kiteAttack{min: 3 * tileSize})
+	return s
+}
+
+func (s *Spitter) Update(dt float64, env *Env) {
+	s.tick(dt, env)
+	s.think(dt, env)
+
+	// spit whenever the machine has us in attack range and the cooldown is up
+	shot := projectile.Projectile{Kind: rpg.Magical, Life: 1.5, Radius: 5, Icon: "shot.acid"}
+	s.fireWhenReady(env, shot, s.spitSpeed)
+}
+
+// AttackIfInRange never lands a melee hit: spitters only attack by spitting.
+func (s *Spitter) AttackIfInRange(px, py float64) bool { return false }
+
+func init() {
+	Register("spitter", func() Enemy {
+		return newSpitter()
+	})
+}
@@ -0,0 +1,66 @@
+package enemies
+
+import (
+	"example.com/go-quest/ai"
+	"example.com/go-quest/rpg"
+)
+
+// Warchief is the goblin boss: knife volleys and charges, then calls for
+// help, then flies into a rage.
+type Warchief struct {
+	BossBase
+}
+
+func newWarchief() Enemy {
+	w := &Warchief{}
+	w.id = "warchief"
+	w.name = "Goblin Warchief"
+	w.icon = "enemy.goblin"
+	w.tint = [3]float64{1.3, 0.7, 0.6}
+	w.scale = 2
+	w.attr = rpg.Attributes{Level: 4, Str: 8, Dex: 5, Int: 6, Vit: 10, Wis: 3, Lck: 3}
+	w.stats = rpg.Recompute(w.attr)
+	w.stats.HP = float64(300)
+	w.stats.HPMax = 300
+	w.hitCooldown = 1.2
+	w.meleeRangePx = 28.0
+	w.moveSpeed = 44.0
+	w.xp = 60
+	w.lootTable = "boss"
+	w.alive = true
+
+	w.phases = []Phase{
+		{Patterns: []Pattern{Volley(3, 30, 2.0), Charge(0.6, 2.5)}},
+		{
+			HPFrac:   0.6,
+			Announce: "The Warchief calls for help!",
+			Mods:     []rpg.Modifier{rpg.Flat{Attack: 4}},
+			Summon:   "goblin", Minions: 2,
+			Patterns: []Pattern{Volley(5, 50, 1.8), Charge(0.6, 2.0), Nova(8, 2.0)},
+		},
+		{
+			HPFrac:   0.3,
+			Announce: "The Warchief flies into a rage!",
+			Mods:     []rpg.Modifier{rpg.Flat{Attack: 4}, rpg.Mult{AttackMul: 1.25, SpeedMul: 1.3}},
+			Summon:   "spitter", Minions: 2,
+			Patterns: []Pattern{Nova(12, 1.5), Charge(0.8, 1.5), Volley(5, 40, 1.2)},
+		},
+	}
+
+	// AI: wait in the arena and don't stray far from it, so the fight
+	// happens where the doors can shut
+	w.brain = ai.New(ai.Params{
+		Rest:        ai.Idle,
+		AggroRadius: 8 * tileSize,
+		LeashRadius: 8 * tileSize,
+		AttackRange: w.meleeRangePx,
+		Memory:      10,
+	})
+	return w
+}
+
+func init() {
+	RegisterBoss("warchief", func() Enemy {
+		return newWarchief()
+	})
+}
@@ -2,37 +2,130 @@ package inventory
 
 import "example.com/go-quest/items"
 
+// Stack is one inventory slot: an item and how many of it. All items in a
+// stack share an ID, so one instance stands in for the lot.
+type Stack struct {
+	Item items.Item
+	Qty  int
+}
+
 type Inventory struct {
-	Slots []items.Item
+	Slots []Stack
 	Max   int
 }
 
 func New(max int) *Inventory {
-	return &Inventory{Max: max, Slots: make([]items.Item, 0, max)}
+	return &Inventory{Max: max, Slots: make([]Stack, 0, max)}
 }
 
+// Add puts one item in the bag, topping up an existing stack of the same
+// item if there is one with room. It returns false if the bag is full.
 func (inv *Inventory) Add(it items.Item) bool {
-	if it == nil || len(inv.Slots) >= inv.Max {
-		return false
+	return inv.AddQty(it, 1) == 0
+}
+
+// AddQty adds n of it, filling existing stacks first and then new slots.
+// It returns how many didn't fit.
+func (inv *Inventory) AddQty(it items.Item, n int) int {
+	if it == nil {
+		return n
 	}
-	inv.Slots = append(inv.Slots, it)
-	return true
+	limit := items.MaxStack(it)
+	for i := range inv.Slots {
+		s := &inv.Slots[i]
+		if n == 0 {
+			break
+		}
+		if s.Item.ID() == it.ID() && s.Qty < limit {
+			k := min(n, limit-s.Qty)
+			s.Qty += k
+			n -= k
+		}
+	}
+	for n > 0 && len(inv.Slots) < inv.Max {
+		k := min(n, limit)
+		inv.Slots = append(inv.Slots, Stack{Item: it, Qty: k})
+		n -= k
+	}
+	return n
 }
 
+// RemoveAt takes the whole stack in slot idx out of the bag and returns
+// its item (nil if idx is out of range).
 func (inv *Inventory) RemoveAt(idx int) items.Item {
 	if idx < 0 || idx >= len(inv.Slots) {
 		return nil
 	}
-	it := inv.Slots[idx]
+	it := inv.Slots[idx].Item
 	inv.Slots = append(inv.Slots[:idx], inv.Slots[idx+1:]...)
 	return it
 }
 
+// TakeOne removes a single item from slot idx, freeing the slot when the
+// stack runs out.
+func (inv *Inventory) TakeOne(idx int) items.Item {
+	if idx < 0 || idx >= len(inv.Slots) {
+		return nil
+	}
+	s := &inv.Slots[idx]
+	if s.Qty > 1 {
+		s.Qty--
+		return s.Item
+	}
+	return inv.RemoveAt(idx)
+}
+
+// Split moves n items from slot idx into a new slot at the end. It fails
+// if there's no free slot or n doesn't leave both halves non-empty.
+func (inv *Inventory) Split(idx, n int) bool {
+	if idx < 0 || idx >= len(inv.Slots) || len(inv.Slots) >= inv.Max {
+		return false
+	}
+	s := &inv.Slots[idx]
+	if n <= 0 || n >= s.Qty {
+		return false
+	}
+	s.Qty -= n
+	inv.Slots = append(inv.Slots, Stack{Item: s.Item, Qty: n})
+	return true
+}
+
+// Merge moves as many items as fit from slot from into slot to (same item
+// only). An emptied source slot is removed. It returns false if nothing
+// moved.
+func (inv *Inventory) Merge(from, to int) bool {
+	if from == to || from < 0 || to < 0 || from >= len(inv.Slots) || to >= len(inv.Slots) {
+		return false
+	}
+	src, dst := &inv.Slots[from], &inv.Slots[to]
+	if src.Item.ID() != dst.Item.ID() {
+		return false
+	}
+	k := min(src.Qty, items.MaxStack(dst.Item)-dst.Qty)
+	if k <= 0 {
+		return false
+	}
+	dst.Qty += k
+	src.Qty -= k
+	if src.Qty == 0 {
+		inv.RemoveAt(from)
+	}
+	return true
+}
+
 func (inv *Inventory) Get(idx int) items.Item {
 	if idx < 0 || idx >= len(inv.Slots) {
 		return nil
 	}
-	return inv.Slots[idx]
+	return inv.Slots[idx].Item
+}
+
+// Qty is the stack size in slot idx (0 if idx is out of range).
+func (inv *Inventory) Qty(idx int) int {
+	if idx < 0 || idx >= len(inv.Slots) {
+		return 0
+	}
+	return inv.Slots[idx].Qty
 }
 
 func (inv *Inventory) Count() int { return len(inv.Slots) }
@@ -0,0 +1,159 @@
+package inventory
+
+import (
+	"testing"
+
+	"example.com/go-quest/player"
+)
+
+// thing is a test item stacking up to max per slot.
+type thing struct {
+	id  string
+	max int
+}
+
+func (t *thing) ID() string                               { return t.id }
+func (t *thing) Name() string                             { return t.id }
+func (t *thing) Icon() string                             { return "" }
+func (t *thing) MaxStack() int                            { return t.max }
+func (t *thing) OnPickup(p *player.Player)                {}
+func (t *thing) OnUse(p *player.Player) bool              { return false }
+func (t *thing) OnDrop(p *player.Player, wx, wy int) bool { return true }
+
+// qtys lists the stack sizes slot by slot.
+func qtys(inv *Inventory) []int {
+	out := make([]int, len(inv.Slots))
+	for i, s := range inv.Slots {
+		out[i] = s.Qty
+	}
+	return out
+}
+
+func sameInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestAddQty(t *testing.T) {
+	arrow := &thing{"arrow", 5}
+	tests := []struct {
+		name     string
+		max      int
+		start    []int // existing arrow stacks
+		add      int
+		wantLeft int
+		want     []int
+	}{
+		{"into an empty bag", 3, nil, 3, 0, []int{3}},
+		{"tops up existing stacks first", 3, []int{4, 2}, 5, 0, []int{5, 5, 1}},
+		{"spills over into new slots", 3, nil, 12, 0, []int{5, 5, 2}},
+		{"returns what didn't fit", 2, []int{4}, 8, 2, []int{5, 5}},
+		{"full bag takes nothing", 1, []int{5}, 3, 3, []int{5}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inv := New(tt.max)
+			for _, q := range tt.start {
+				inv.Slots = append(inv.Slots, Stack{Item: arrow, Qty: q})
+			}
+			if left := inv.AddQty(arrow, tt.add); left != tt.wantLeft {
+				t.Errorf("left over = %d, want %d", left, tt.wantLeft)
+			}
+			if got := qtys(inv); !sameInts(got, tt.want) {
+				t.Errorf("stacks = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAddQtyKeepsItemsApart(t *testing.T) {
+	inv := New(4)
+	inv.AddQty(&thing{"arrow", 5}, 2)
+	inv.AddQty(&thing{"bolt", 5}, 2)
+	inv.AddQty(&thing{"sword", 1}, 2)
+	if got, want := qtys(inv), []int{2, 2, 1, 1}; !sameInts(got, want) {
+		t.Fatalf("stacks = %v, want %v", got, want)
+	}
+}
+
+func TestTakeOne(t *testing.T) {
+	arrow := &thing{"arrow", 5}
+	inv := New(3)
+	inv.AddQty(arrow, 2)
+
+	if it := inv.TakeOne(0); it != arrow || inv.Qty(0) != 1 {
+		t.Fatalf("first take: got %v, qty %d; want the arrow and 1 left", it, inv.Qty(0))
+	}
+	if it := inv.TakeOne(0); it != arrow || inv.Count() != 0 {
+		t.Fatalf("last take: got %v, %d slots; want the arrow and the slot freed", it, inv.Count())
+	}
+	if it := inv.TakeOne(0); it != nil {
+		t.Fatalf("take from an empty bag = %v, want nil", it)
+	}
+}
+
+func TestSplit(t *testing.T) {
+	tests := []struct {
+		name string
+		max  int
+		n    int
+		ok   bool
+		want []int
+	}{
+		{"half", 3, 2, true, []int{3, 2}},
+		{"one off", 3, 1, true, []int{4, 1}},
+		{"nothing", 3, 0, false, []int{5}},
+		{"the whole stack", 3, 5, false, []int{5}},
+		{"more than the stack", 3, 7, false, []int{5}},
+		{"no free slot", 1, 2, false, []int{5}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inv := New(tt.max)
+			inv.AddQty(&thing{"arrow", 5}, 5)
+			if ok := inv.Split(0, tt.n); ok != tt.ok {
+				t.Errorf("Split(0, %d) = %v, want %v", tt.n, ok, tt.ok)
+			}
+			if got := qtys(inv); !sameInts(got, tt.want) {
+				t.Errorf("stacks = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMerge(t *testing.T) {
+	arrow, bolt := &thing{"arrow", 5}, &thing{"bolt", 5}
+	tests := []struct {
+		name     string
+		slots    []Stack
+		from, to int
+		ok       bool
+		want     []int
+	}{
+		{"all of it fits", []Stack{{arrow, 2}, {arrow, 1}}, 0, 1, true, []int{3}},
+		{"up to the stack limit", []Stack{{arrow, 4}, {arrow, 3}}, 0, 1, true, []int{2, 5}},
+		{"target already full", []Stack{{arrow, 2}, {arrow, 5}}, 0, 1, false, []int{2, 5}},
+		{"different items", []Stack{{arrow, 2}, {bolt, 1}}, 0, 1, false, []int{2, 1}},
+		{"into itself", []Stack{{arrow, 2}}, 0, 0, false, []int{2}},
+		{"out of range", []Stack{{arrow, 2}}, 0, 3, false, []int{2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inv := New(4)
+			inv.Slots = append(inv.Slots, tt.slots...)
+			if ok := inv.Merge(tt.from, tt.to); ok != tt.ok {
+				t.Errorf("Merge(%d, %d) = %v, want %v", tt.from, tt.to, ok, tt.ok)
+			}
+			if got := qtys(inv); !sameInts(got, tt.want) {
+				t.Errorf("stacks = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,270 @@
+package items
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"example.com/go-quest/player"
+	"example.com/go-quest/rpg"
+)
+
+// Def is an item defined in a data file rather than in Go. A file holds
+// {"items": [Def, ...]}; modifier fields use the rpg.Flat / rpg.Mult
+// field names, e.g. "flat": {"Defense": 2, "MoveSpeed": -5}. Unknown
+// modifier and buff fields are an error, so typos show up.
+type Def struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Icon  string `json:"icon"`  // atlas key
+	Stack int    `json:"stack"` // max per inventory slot; 0 or 1 = doesn't stack
+
+	// Equipment: a slot ("head", "body", "feet", "weapon", "offhand",
+	// "ring") makes the item wearable; Flat/Mult apply while worn.
+	Slot string          `json:"slot,omitempty"`
+	Flat json.RawMessage `json:"flat,omitempty"` // rpg.Flat fields
+	Mult json.RawMessage `json:"mult,omitempty"` // rpg.Mult fields
+
+	// Consumables: using the item applies Use and uses one up.
+	Use *UseDef `json:"use,omitempty"`
+
+	mods []rpg.Modifier // Flat and Mult, decoded by validate
+}
+
+// UseDef is what a consumable does when used.
+type UseDef struct {
+	HealPct float64         `json:"heal_pct,omitempty"` // fraction of max HP, e.g. 0.3
+	MP      float64         `json:"mp,omitempty"`       // MP restored
+	Stamina float64         `json:"stamina,omitempty"`  // stamina restored
+	Buff    json.RawMessage `json:"buff,omitempty"`     // a BuffDef
+
+	buff *BuffDef // decoded by validate
+}
+
+// BuffDef describes a timed status effect (see rpg.Effect).
+type BuffDef struct {
+	ID        string          `json:"id"`
+	Name      string          `json:"name"`
+	Duration  float64         `json:"duration"`             // seconds
+	Stack     string          `json:"stack,omitempty"`      // "refresh" (default), "stack" or "ignore"
+	MaxStacks int             `json:"max_stacks,omitempty"` // for "stack"
+	Flat      json.RawMessage `json:"flat,omitempty"`       // rpg.Flat fields
+	Mult      json.RawMessage `json:"mult,omitempty"`       // rpg.Mult fields
+	HPPerSec  float64         `json:"hp_per_sec,omitempty"` // >0 heals, <0 hurts, per stack
+
+	mods []rpg.Modifier // Flat and Mult, decoded by validate
+}
+
+type defFile struct {
+	Items []Def `json:"items"`
+}
+
+// LoadDir loads every *.json file in dir (in name order) with LoadFile.
+func LoadDir(dir string) error {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return err
+	}
+	sort.Strings(paths)
+	for _, p := range paths {
+		if err := LoadFile(p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LoadFile reads item definitions from a JSON file and registers each one
+// with Register, next to the Go-coded items. Defining an ID that's already
+// registered is an error, so data can't silently replace code. The whole
+// file is checked first: if any definition is bad, none are registered.
+func LoadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("items: %w", err)
+	}
+	var f defFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return fmt.Errorf("items: %s: %w", path, err)
+	}
+	seen := map[string]bool{}
+	for i := range f.Items {
+		d := &f.Items[i]
+		if err := d.validate(); err != nil {
+			return fmt.Errorf("items: %s: %w", path, err)
+		}
+		if _, dup := registry[d.ID]; dup {
+			return fmt.Errorf("items: %s: id %q is already registered", path, d.ID)
+		}
+		if seen[d.ID] {
+			return fmt.Errorf("items: %s: id %q is defined twice", path, d.ID)
+		}
+		seen[d.ID] = true
+	}
+	for _, d := range f.Items {
+		Register(d.ID, d.ctor())
+	}
+	return nil
+}
+
+func (d *Def) validate() error {
+	if d.ID == "" {
+		return fmt.Errorf("item without an id")
+	}
+	if d.Name == "" {
+		d.Name = d.ID
+	}
+	if d.Slot != "" {
+		if _, ok := slotByName(d.Slot); !ok {
+			return fmt.Errorf("item %q: unknown slot %q", d.ID, d.Slot)
+		}
+		if d.Use != nil {
+			return fmt.Errorf("item %q: equipment can't have a use effect", d.ID)
+		}
+	}
+	mods, err := modsOf(d.Flat, d.Mult)
+	if err != nil {
+		return fmt.Errorf("item %q: %w", d.ID, err)
+	}
+	d.mods = mods
+	if u := d.Use; u != nil && len(u.Buff) > 0 {
+		b := &BuffDef{}
+		if err := decodeStrict(u.Buff, b); err != nil {
+			return fmt.Errorf("item %q: buff: %w", d.ID, err)
+		}
+		if b.ID == "" {
+			return fmt.Errorf("item %q: buff without an id", d.ID)
+		}
+		if _, ok := stackRules[b.Stack]; !ok {
+			return fmt.Errorf("item %q: unknown stack rule %q", d.ID, b.Stack)
+		}
+		if b.mods, err = modsOf(b.Flat, b.Mult); err != nil {
+			return fmt.Errorf("item %q: buff: %w", d.ID, err)
+		}
+		u.buff = b
+	}
+	return nil
+}
+
+var stackRules = map[string]rpg.StackRule{
+	"": rpg.Refresh, "refresh": rpg.Refresh, "stack": rpg.Stack, "ignore": rpg.Ignore,
+}
+
+func slotByName(name string) (player.Slot, bool) {
+	for s := player.Slot(0); s < player.NumSlots; s++ {
+		if s.String() == name {
+			return s, true
+		}
+	}
+	return 0, false
+}
+
+// ctor builds the registry constructor for d: equipment gets a type that
+// implements Equipment, everything else a plain item.
+func (d Def) ctor() Ctor {
+	return func() Item {
+		it := &dataItem{def: &d}
+		if slot, ok := slotByName(d.Slot); ok {
+			return &dataGear{dataItem: it, slot: slot}
+		}
+		return it
+	}
+}
+
+// dataItem is an item built from a Def.
+type dataItem struct {
+	def *Def
+}
+
+func (it *dataItem) ID() string    { return it.def.ID }
+func (it *dataItem) Name() string  { return it.def.Name }
+func (it *dataItem) Icon() string  { return it.def.Icon }
+func (it *dataItem) MaxStack() int { return it.def.Stack }
+
+func (it *dataItem) OnPickup(p *player.Player) {}
+
+func (it *dataItem) OnUse(p *player.Player) bool {
+	u := it.def.Use
+	if u == nil {
+		return false
+	}
+	s := &p.Stats
+	s.HP = min(s.HP+u.HealPct*float64(s.HPMax), float64(s.HPMax))
+	s.MP = min(s.MP+u.MP, float64(s.MPMax))
+	s.Stamina = min(s.Stamina+u.Stamina, float64(s.StaminaMax))
+	if u.buff != nil {
+		p.AddEffect(u.buff.effect())
+	}
+	return true // consumed
+}
+
+func (it *dataItem) OnDrop(p *player.Player, wx, wy int) bool { return true }
+
+// dataGear is a wearable item built from a Def.
+type dataGear struct {
+	*dataItem
+	slot player.Slot
+}
+
+func (g *dataGear) Slot() player.Slot    { return g.slot }
+func (g *dataGear) Mods() []rpg.Modifier { return g.def.mods }
+func (g *dataGear) MaxStack() int        { return 1 }
+
+func (g *dataGear) OnUse(p *player.Player) bool {
+	ToggleEquip(p, g)
+	return false // not consumed
+}
+
+func (g *dataGear) OnDrop(p *player.Player, wx, wy int) bool {
+	UnequipIfWorn(p, g)
+	return true
+}
+
+// effect turns the buff definition into an rpg.Effect.
+func (b *BuffDef) effect() rpg.Effect {
+	e := rpg.Effect{
+		ID:        rpg.EffectID(b.ID),
+		Name:      b.Name,
+		Duration:  b.Duration,
+		Stack:     stackRules[b.Stack],
+		MaxStacks: b.MaxStacks,
+		Mods:      b.mods,
+	}
+	if hps := b.HPPerSec; hps != 0 {
+		e.OnTick = func(s *rpg.Stats, n int) {
+			s.HP = min(max(s.HP+hps*float64(n), 0), float64(s.HPMax))
+		}
+	}
+	return e
+}
+
+// modsOf decodes the flat and mult JSON of a definition (either may be
+// empty) into modifiers.
+func modsOf(flat, mult json.RawMessage) ([]rpg.Modifier, error) {
+	var out []rpg.Modifier
+	if len(flat) > 0 {
+		var f rpg.Flat
+		if err := decodeStrict(flat, &f); err != nil {
+			return nil, fmt.Errorf("flat: %w", err)
+		}
+		out = append(out, f)
+	}
+	if len(mult) > 0 {
+		var m rpg.Mult
+		if err := decodeStrict(mult, &m); err != nil {
+			return nil, fmt.Errorf("mult: %w", err)
+		}
+		out = append(out, m)
+	}
+	return out, nil
+}
+
+// decodeStrict unmarshals raw into v, rejecting fields v doesn't have.
+func decodeStrict(raw json.RawMessage, v any) error {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.DisallowUnknownFields()
+	return dec.Decode(v)
+}
@@ -0,0 +1,95 @@
+package items
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeDefs writes {"items": [defs...]} to a temp file and returns its path.
+func writeDefs(t *testing.T, defs ...string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "items.json")
+	data := `{"items": [` + strings.Join(defs, ",") + `]}`
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadFileRejects(t *testing.T) {
+	tests := []struct {
+		name string
+		defs []string
+		want string // in the error
+	}{
+		{
+			"typo in flat",
+			[]string{`{"id": "t_cap", "slot": "head", "flat": {"Defence": 1}}`},
+			`unknown field "Defence"`,
+		},
+		{
+			"typo in mult",
+			[]string{`{"id": "t_cap", "slot": "head", "mult": {"SpeedMult": 1.1}}`},
+			`unknown field "SpeedMult"`,
+		},
+		{
+			"typo in buff",
+			[]string{`{"id": "t_fig", "use": {"buff": {"id": "haste", "durration": 5}}}`},
+			`unknown field "durration"`,
+		},
+		{
+			"typo in buff modifiers",
+			[]string{`{"id": "t_fig", "use": {"buff": {"id": "haste", "duration": 5, "mult": {"Speed": 2}}}}`},
+			`unknown field "Speed"`,
+		},
+		{
+			"unknown slot",
+			[]string{`{"id": "t_cap", "slot": "hat"}`},
+			`unknown slot "hat"`,
+		},
+		{
+			"defined twice",
+			[]string{`{"id": "t_fig"}`, `{"id": "t_fig"}`},
+			`"t_fig" is defined twice`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := LoadFile(writeDefs(t, tt.defs...))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("LoadFile error = %v, want one mentioning %s", err, tt.want)
+			}
+			if New("t_cap") != nil || New("t_fig") != nil {
+				t.Fatalf("a definition was registered from a bad file")
+			}
+		})
+	}
+}
+
+func TestLoadFile(t *testing.T) {
+	path := writeDefs(t,
+		`{"id": "t_helm", "name": "Test Helm", "slot": "head", "flat": {"Defense": 3}, "mult": {"SpeedMul": 0.9}}`,
+		`{"id": "t_berry", "stack": 5, "use": {"buff": {"id": "haste", "duration": 5, "mult": {"SpeedMul": 1.5}}}}`,
+	)
+	if err := LoadFile(path); err != nil {
+		t.Fatal(err)
+	}
+	helm, ok := New("t_helm").(Equipment)
+	if !ok {
+		t.Fatalf("t_helm isn't equipment")
+	}
+	if n := len(helm.Mods()); n != 2 {
+		t.Fatalf("t_helm has %d modifiers, want 2", n)
+	}
+	berry := New("t_berry")
+	if berry == nil || berry.Name() != "t_berry" || MaxStack(berry) != 5 {
+		t.Fatalf("t_berry = %v, want a stack of 5 named after its id", berry)
+	}
+
+	// loading the same file again would replace registered items
+	if err := LoadFile(path); err == nil {
+		t.Fatalf("loaded the same ids twice")
+	}
+}
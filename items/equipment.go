@@ -0,0 +1,27 @@
+package items
+
+import "example.com/go-quest/player"
+
+// Equipment is an item that can be worn. It stays in the inventory while
+// worn; using it (Enter) puts it on or takes it off.
+type Equipment interface {
+	Item
+	player.Gear
+}
+
+// ToggleEquip wears e, or takes it off if it's already worn. Whatever was
+// in the slot before is simply unequipped (it stays in the inventory).
+func ToggleEquip(p *player.Player, e Equipment) {
+	if p.IsEquipped(e) {
+		p.Unequip(e.Slot())
+		return
+	}
+	p.Equip(e)
+}
+
+// UnequipIfWorn takes e off before it leaves the inventory.
+func UnequipIfWorn(p *player.Player, e Equipment) {
+	if p.IsEquipped(e) {
+		p.Unequip(e.Slot())
+	}
+}
@@ -1,18 +1,14 @@
 package items
 
-import (
-	"example.com/go-quest/atlas"
-	"example.com/go-quest/player"
-	"github.com/hajimehoshi/ebiten/v2"
-)
-
-type HealthPotion struct {
-	icon *ebiten.Image
-}
+import "example.com/go-quest/player"
+
+type HealthPotion struct{}
+
+func (h *HealthPotion) ID() string   { return "health_potion" }
+func (h *HealthPotion) Name() string { return "Health Potion" }
+func (h *HealthPotion) Icon() string { return "icon.hp" } // register this in atlas (or load single)
 
-func (h *HealthPotion) ID() string         { return "health_potion" }
-func (h *HealthPotion) Name() string       { return "Health Potion" }
-func (h *HealthPotion) Icon() *ebiten.Image { return h.icon }
+func (h *HealthPotion) MaxStack() int { return 10 }
 
 func (h *HealthPotion) OnPickup(p *player.Player) {}
 
@@ -31,8 +27,7 @@ func (h *HealthPotion) OnDrop(p *player.Player, wx, wy int) bool {
 }
 
 func init() {
-	Register("health_potion", func(atl *atlas.Atlas) Item {
-		img, _ := atl.Get("icon.hp") // register this in atlas (or load single)
-		return &HealthPotion{icon: img}
+	Register("health_potion", func() Item {
+		return &HealthPotion{}
 	})
 }
@@ -1,8 +1,8 @@
 package items
 
 import (
-	"github.com/hajimehoshi/ebiten/v2"
-	"example.com/go-quest/atlas"
+	"sort"
+
 	"example.com/go-quest/player"
 )
 
@@ -10,17 +10,31 @@ import (
 type Item interface {
 	ID() string
 	Name() string
-	Icon() *ebiten.Image
+	Icon() string // atlas key of the item's sprite (drawn by the game)
 
 	// Called when player picks this up (e.g., auto-apply buff or stack)
-	OnPickup(p *player.Player)                 // optional behavior; keep fast
-	OnUse(p *player.Player) bool               // return true if consumed/changed
-	OnDrop(p *player.Player, wx, wy int) bool  // return true if dropped in world
+	OnPickup(p *player.Player)                // optional behavior; keep fast
+	OnUse(p *player.Player) bool              // return true if consumed/changed
+	OnDrop(p *player.Player, wx, wy int) bool // return true if dropped in world
+}
+
+// Stackable is implemented by items that share an inventory slot with
+// others of the same ID, up to MaxStack of them.
+type Stackable interface {
+	MaxStack() int
+}
+
+// MaxStack returns how many of it fit in one slot (1 unless it's Stackable).
+func MaxStack(it Item) int {
+	if s, ok := it.(Stackable); ok && s.MaxStack() > 1 {
+		return s.MaxStack()
+	}
+	return 1
 }
 
 // -------- Registry so items can self-register via init() --------
 
-type Ctor func(atl *atlas.Atlas) Item
+type Ctor func() Item
 
 var registry = map[string]Ctor{}
 
@@ -28,17 +42,19 @@ func Register(id string, c Ctor) {
 	registry[id] = c
 }
 
-func New(id string, atl *atlas.Atlas) Item {
+func New(id string) Item {
 	if c, ok := registry[id]; ok {
-		return c(atl)
+		return c()
 	}
 	return nil
 }
 
+// AllIDs returns the registered ids in sorted order, so seeded picks are stable.
 func AllIDs() []string {
 	out := make([]string, 0, len(registry))
 	for k := range registry {
 		out = append(out, k)
 	}
+	sort.Strings(out)
 	return out
 }
@@ -0,0 +1,36 @@
+package items
+
+import (
+	"example.com/go-quest/player"
+	"example.com/go-quest/rpg"
+)
+
+type ShortSword struct{}
+
+func (s *ShortSword) ID() string   { return "short_sword" }
+func (s *ShortSword) Name() string { return "Short Sword" }
+func (s *ShortSword) Icon() string { return "icon.sword" }
+
+func (s *ShortSword) Slot() player.Slot { return player.SlotWeapon }
+
+func (s *ShortSword) Mods() []rpg.Modifier {
+	return []rpg.Modifier{rpg.Flat{Attack: 3}}
+}
+
+func (s *ShortSword) OnPickup(p *player.Player) {}
+
+func (s *ShortSword) OnUse(p *player.Player) bool {
+	ToggleEquip(p, s)
+	return false // not consumed
+}
+
+func (s *ShortSword) OnDrop(p *player.Player, wx, wy int) bool {
+	UnequipIfWorn(p, s)
+	return true
+}
+
+func init() {
+	Register("short_sword", func() Item {
+		return &ShortSword{}
+	})
+}
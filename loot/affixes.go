@@ -0,0 +1,59 @@
+package loot
+
+import "example.com/go-quest/rpg"
+
+// affix is a template for AffixRoll. roll scales its value by power
+// (deeper floors roll bigger numbers).
+type affix struct {
+	name   string
+	suffix bool
+	roll   func(power float64) (*rpg.Flat, *rpg.Mult)
+}
+
+func flat(f rpg.Flat) (*rpg.Flat, *rpg.Mult) { return &f, nil }
+func mult(m rpg.Mult) (*rpg.Flat, *rpg.Mult) { return nil, &m }
+
+// n rounds v*power to an int of at least 1.
+func n(v, power float64) int { return max(int(v*power+0.5), 1) }
+
+var prefixes = []affix{
+	{name: "Swift", roll: func(p float64) (*rpg.Flat, *rpg.Mult) { return flat(rpg.Flat{MoveSpeed: 8 * p}) }},
+	{name: "Sturdy", roll: func(p float64) (*rpg.Flat, *rpg.Mult) { return flat(rpg.Flat{Defense: n(1.5, p)}) }},
+	{name: "Mighty", roll: func(p float64) (*rpg.Flat, *rpg.Mult) { return flat(rpg.Flat{Attack: n(2, p)}) }},
+	{name: "Keen", roll: func(p float64) (*rpg.Flat, *rpg.Mult) { return flat(rpg.Flat{CritChance: 0.02 * p}) }},
+	{name: "Vital", roll: func(p float64) (*rpg.Flat, *rpg.Mult) { return flat(rpg.Flat{HPMax: n(8, p)}) }},
+	{name: "Brutal", roll: func(p float64) (*rpg.Flat, *rpg.Mult) { return mult(rpg.Mult{AttackMul: 1 + 0.05*p}) }},
+}
+
+var suffixes = []affix{
+	{name: "of the Bear", suffix: true, roll: func(p float64) (*rpg.Flat, *rpg.Mult) { return flat(rpg.Flat{HPMax: n(6, p), Defense: n(1, p)}) }},
+	{name: "of the Fox", suffix: true, roll: func(p float64) (*rpg.Flat, *rpg.Mult) { return flat(rpg.Flat{CritMult: 0.1 * p}) }},
+	{name: "of the Owl", suffix: true, roll: func(p float64) (*rpg.Flat, *rpg.Mult) { return flat(rpg.Flat{MPMax: n(6, p), Magic: n(1, p)}) }},
+	{name: "of the Mule", suffix: true, roll: func(p float64) (*rpg.Flat, *rpg.Mult) { return flat(rpg.Flat{StaminaMax: n(10, p)}) }},
+	{name: "of Warding", suffix: true, roll: func(p float64) (*rpg.Flat, *rpg.Mult) { return flat(rpg.Flat{Resist: n(2, p)}) }},
+	{name: "of Haste", suffix: true, roll: func(p float64) (*rpg.Flat, *rpg.Mult) { return mult(rpg.Mult{SpeedMul: 1 + 0.04*p}) }},
+}
+
+// unique is a hand-made item on a fixed base with fixed modifiers.
+type unique struct {
+	name, base string
+	minDepth   int
+	affixes    []AffixRoll
+}
+
+var uniques = []unique{
+	{name: "Windwalkers", base: "boots_haste", affixes: []AffixRoll{
+		{Name: "Windwalkers", Flat: &rpg.Flat{MoveSpeed: 30, Defense: 2}},
+		{Name: "Windwalkers", Suffix: true, Mult: &rpg.Mult{SpeedMul: 1.10}},
+	}},
+	{name: "Goblin Toothpick", base: "short_sword", affixes: []AffixRoll{
+		{Name: "Goblin Toothpick", Flat: &rpg.Flat{Attack: 6, CritChance: 0.10, CritMult: 0.5}},
+	}},
+	{name: "Ironbark Buckler", base: "buckler", minDepth: 2, affixes: []AffixRoll{
+		{Name: "Ironbark Buckler", Flat: &rpg.Flat{Defense: 6, Resist: 4, HPMax: 20}},
+	}},
+	{name: "Band of the Lucky", base: "copper_ring", minDepth: 3, affixes: []AffixRoll{
+		{Name: "Band of the Lucky", Flat: &rpg.Flat{CritChance: 0.08, CritMult: 0.25}},
+		{Name: "Band of the Lucky", Suffix: true, Mult: &rpg.Mult{AttackMul: 1.10}},
+	}},
+}
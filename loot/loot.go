@@ -0,0 +1,124 @@
+// Package loot rolls randomized equipment: a registered base item plus a
+// rarity and prefix/suffix affixes, each worth an rpg modifier.
+package loot
+
+import (
+	"fmt"
+
+	"example.com/go-quest/items"
+	"example.com/go-quest/player"
+	"example.com/go-quest/rpg"
+)
+
+// Rarity is a loot quality tier.
+type Rarity int
+
+const (
+	Common Rarity = iota
+	Magic
+	Rare
+	Unique
+)
+
+func (r Rarity) String() string {
+	switch r {
+	case Magic:
+		return "magic"
+	case Rare:
+		return "rare"
+	case Unique:
+		return "unique"
+	}
+	return "common"
+}
+
+// AffixRoll is one rolled affix: its name and the modifier it grants.
+// Exactly one of Flat/Mult is set.
+type AffixRoll struct {
+	Name   string    `json:"name"`
+	Suffix bool      `json:"suffix,omitempty"`
+	Flat   *rpg.Flat `json:"flat,omitempty"`
+	Mult   *rpg.Mult `json:"mult,omitempty"`
+}
+
+// Spec fully describes a rolled item, so it can be saved and rebuilt.
+type Spec struct {
+	Base    string      `json:"base"` // registered equipment id
+	Rarity  Rarity      `json:"rarity"`
+	Name    string      `json:"name,omitempty"` // uniques have their own name
+	Affixes []AffixRoll `json:"affixes,omitempty"`
+}
+
+// Item is a rolled piece of equipment. It wears like its base item, with
+// the affix modifiers added on top, and is never stacked with others.
+type Item struct {
+	items.Equipment // the base item
+	Spec            Spec
+	name            string
+}
+
+// Build turns a Spec back into an Item, instancing the base through the
+// items registry.
+func Build(s Spec) (*Item, error) {
+	base, ok := items.New(s.Base).(items.Equipment)
+	if !ok {
+		return nil, fmt.Errorf("loot: %q is not registered equipment", s.Base)
+	}
+	return &Item{Equipment: base, Spec: s, name: displayName(s, base.Name())}, nil
+}
+
+// displayName is "Prefix Base of Suffix" from the first prefix and suffix
+// rolled, or the unique's own name.
+func displayName(s Spec, base string) string {
+	if s.Name != "" {
+		return s.Name
+	}
+	name := base
+	prefix, suffix := "", ""
+	for _, a := range s.Affixes {
+		switch {
+		case !a.Suffix && prefix == "":
+			prefix = a.Name
+		case a.Suffix && suffix == "":
+			suffix = a.Name
+		}
+	}
+	if prefix != "" {
+		name = prefix + " " + name
+	}
+	if suffix != "" {
+		name += " " + suffix
+	}
+	return name
+}
+
+func (it *Item) Name() string   { return it.name }
+func (it *Item) Rarity() Rarity { return it.Spec.Rarity }
+func (it *Item) MaxStack() int  { return 1 }
+
+// Mods are the base item's modifiers followed by every affix.
+func (it *Item) Mods() []rpg.Modifier {
+	mods := append([]rpg.Modifier{}, it.Equipment.Mods()...)
+	for _, a := range it.Spec.Affixes {
+		if a.Flat != nil {
+			mods = append(mods, *a.Flat)
+		}
+		if a.Mult != nil {
+			mods = append(mods, *a.Mult)
+		}
+	}
+	return mods
+}
+
+// OnUse and OnDrop act on the rolled item itself, not the base inside it,
+// so the right instance is worn.
+
+func (it *Item) OnUse(p *player.Player) bool {
+	items.ToggleEquip(p, it)
+	return false
+}
+
+func (it *Item) OnDrop(p *player.Player, wx, wy int) bool {
+	items.UnequipIfWorn(p, it)
+	return true
+}
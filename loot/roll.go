@@ -0,0 +1,123 @@
+package loot
+
+import (
+	"math/rand/v2"
+
+	"example.com/go-quest/items"
+)
+
+// RollRarity picks a tier. Common stays at a flat weight while the better
+// tiers grow with depth, so deeper floors drop better loot.
+func RollRarity(r *rand.Rand, depth int) Rarity {
+	d := float64(max(depth, 0))
+	weights := []float64{100, 25 + 5*d, 4 + 2*d, 0.5 + 0.5*d}
+	total := 0.0
+	for _, w := range weights {
+		total += w
+	}
+	x := r.Float64() * total
+	for i, w := range weights {
+		if x < w {
+			return Rarity(i)
+		}
+		x -= w
+	}
+	return Common
+}
+
+// Roll makes a random item for a floor at depth: a random registered
+// equipment base with a rolled rarity and affixes. It returns nil if no
+// equipment is registered.
+func Roll(r *rand.Rand, depth int) *Item {
+	s, ok := RollSpec(r, depth)
+	if !ok {
+		return nil
+	}
+	it, err := Build(s)
+	if err != nil {
+		return nil
+	}
+	return it
+}
+
+// RollSpec rolls the description of an item without building it.
+func RollSpec(r *rand.Rand, depth int) (Spec, bool) {
+	rarity := RollRarity(r, depth)
+	if rarity == Unique {
+		if s, ok := rollUnique(r, depth); ok {
+			return s, true
+		}
+		rarity = Rare // no unique fits: the best we can do
+	}
+
+	bases := equipmentIDs()
+	if len(bases) == 0 {
+		return Spec{}, false
+	}
+	s := Spec{Base: bases[r.IntN(len(bases))], Rarity: rarity}
+
+	// how many prefixes / suffixes
+	np, ns := 0, 0
+	switch rarity {
+	case Magic: // one or the other, sometimes both
+		switch r.IntN(3) {
+		case 0:
+			np = 1
+		case 1:
+			ns = 1
+		default:
+			np, ns = 1, 1
+		}
+	case Rare: // three or four, at most two of each
+		if r.IntN(2) == 0 {
+			np, ns = 2, 2
+		} else {
+			np = 1 + r.IntN(2)
+			ns = 3 - np
+		}
+	}
+
+	power := 1 + 0.25*float64(max(depth, 0))
+	for _, list := range []struct {
+		affixes []affix
+		n       int
+	}{{prefixes, np}, {suffixes, ns}} {
+		for _, i := range r.Perm(len(list.affixes))[:list.n] {
+			a := list.affixes[i]
+			f, m := a.roll(power * (0.8 + 0.4*r.Float64())) // ±20% per roll
+			s.Affixes = append(s.Affixes, AffixRoll{Name: a.name, Suffix: a.suffix, Flat: f, Mult: m})
+		}
+	}
+	return s, true
+}
+
+// rollUnique picks one of the uniques allowed at depth whose base exists.
+func rollUnique(r *rand.Rand, depth int) (Spec, bool) {
+	var fits []unique
+	for _, u := range uniques {
+		if u.minDepth <= depth && isEquipment(u.base) {
+			fits = append(fits, u)
+		}
+	}
+	if len(fits) == 0 {
+		return Spec{}, false
+	}
+	u := fits[r.IntN(len(fits))]
+	return Spec{Base: u.base, Rarity: Unique, Name: u.name, Affixes: u.affixes}, true
+}
+
+// equipmentIDs lists every registered item that can be worn, in id order.
+func equipmentIDs() []string {
+	var out []string
+	for _, id := range items.AllIDs() {
+		if isEquipment(id) {
+			out = append(out, id)
+		}
+	}
+	return out
+}
+
+func isEquipment(id string) bool {
+	_, ok := items.New(id).(items.Equipment)
+	return ok
+}
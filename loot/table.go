@@ -0,0 +1,138 @@
+package loot
+
+import (
+	"math/rand/v2"
+	"sort"
+
+	"example.com/go-quest/items"
+)
+
+// Entry is one outcome of a Table. Set one of Item, Gold, Table or Rolled;
+// an entry with none of them is "nothing" (useful to tune drop rates).
+type Entry struct {
+	Weight int `json:"weight"` // relative chance among a table's Entries
+
+	Item   string `json:"item,omitempty"`    // registered item id...
+	MinQty int    `json:"min_qty,omitempty"` // ...times MinQty..MaxQty (0 = 1)
+	MaxQty int    `json:"max_qty,omitempty"`
+
+	MinGold int `json:"min_gold,omitempty"` // a gold pile, scaled up with depth
+	MaxGold int `json:"max_gold,omitempty"`
+
+	Table  string `json:"table,omitempty"`  // roll another table
+	Rolled bool   `json:"rolled,omitempty"` // random gear from Roll
+}
+
+// Table is a declarative loot table: everything in Always drops, then
+// MinRolls..MaxRolls weighted picks are made from Entries.
+type Table struct {
+	Always   []Entry `json:"always,omitempty"`
+	Entries  []Entry `json:"entries,omitempty"`
+	MinRolls int     `json:"min_rolls,omitempty"` // 0 = 1
+	MaxRolls int     `json:"max_rolls,omitempty"`
+}
+
+// Drop is one thing a table produced: an item instance or a gold amount.
+type Drop struct {
+	Item items.Item // nil for gold
+	Gold int
+}
+
+var tables = map[string]*Table{}
+
+// RegisterTable adds (or replaces) loot table id.
+func RegisterTable(id string, t *Table) { tables[id] = t }
+
+// HasTable reports whether table id is registered.
+func HasTable(id string) bool { _, ok := tables[id]; return ok }
+
+// TableIDs returns the registered table ids in sorted order.
+func TableIDs() []string {
+	out := make([]string, 0, len(tables))
+	for k := range tables {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// maxNesting stops tables that (accidentally) include each other.
+const maxNesting = 8
+
+// Generate rolls table id for a floor at depth. Unknown tables and item
+// ids drop nothing, so content can be removed without breaking tables.
+func Generate(id string, r *rand.Rand, depth int) []Drop {
+	var out []Drop
+	generate(id, r, depth, 0, &out)
+	return out
+}
+
+func generate(id string, r *rand.Rand, depth int, nest int, out *[]Drop) {
+	t := tables[id]
+	if t == nil || nest > maxNesting {
+		return
+	}
+	for _, e := range t.Always {
+		e.drop(r, depth, nest, out)
+	}
+	if len(t.Entries) == 0 {
+		return
+	}
+	rolls := max(t.MinRolls, 1)
+	if t.MaxRolls > rolls {
+		rolls += r.IntN(t.MaxRolls - rolls + 1)
+	}
+	for i := 0; i < rolls; i++ {
+		if e := t.pick(r); e != nil {
+			e.drop(r, depth, nest, out)
+		}
+	}
+}
+
+// pick chooses an entry by weight (nil if all weights are zero).
+func (t *Table) pick(r *rand.Rand) *Entry {
+	total := 0
+	for _, e := range t.Entries {
+		total += max(e.Weight, 0)
+	}
+	if total == 0 {
+		return nil
+	}
+	x := r.IntN(total)
+	for i := range t.Entries {
+		e := &t.Entries[i]
+		if x < max(e.Weight, 0) {
+			return e
+		}
+		x -= max(e.Weight, 0)
+	}
+	return nil
+}
+
+func (e *Entry) drop(r *rand.Rand, depth int, nest int, out *[]Drop) {
+	switch {
+	case e.Table != "":
+		generate(e.Table, r, depth, nest+1, out)
+	case e.Rolled:
+		if it := Roll(r, depth); it != nil {
+			*out = append(*out, Drop{Item: it})
+		}
+	case e.Item != "":
+		qty := max(e.MinQty, 1)
+		if e.MaxQty > qty {
+			qty += r.IntN(e.MaxQty - qty + 1)
+		}
+		for i := 0; i < qty; i++ {
+			if it := items.New(e.Item); it != nil {
+				*out = append(*out, Drop{Item: it})
+			}
+		}
+	case e.MaxGold > 0:
+		g := e.MinGold
+		if e.MaxGold > g {
+			g += r.IntN(e.MaxGold - g + 1)
+		}
+		g = int(float64(g) * (1 + 0.25*float64(max(depth, 0)))) // richer deeper down
+		*out = append(*out, Drop{Gold: max(g, 1)})
+	}
+}
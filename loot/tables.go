@@ -0,0 +1,65 @@
+package loot
+
+// Built-in tables. Enemy types name theirs (enemies.Enemy.LootTable) and
+// the world picks a "room.*" table per room when it builds a floor.
+func init() {
+	RegisterTable("consumables", &Table{Entries: []Entry{
+		{Weight: 40, Item: "health_potion"},
+		{Weight: 25, Item: "strawberry", MinQty: 1, MaxQty: 3},
+		{Weight: 20, Item: "apple", MinQty: 1, MaxQty: 2},
+		{Weight: 10, Item: "swift_berries"},
+		{Weight: 5, Item: "troll_grapes"},
+	}})
+
+	// enemies
+	RegisterTable("slime", &Table{Entries: []Entry{
+		{Weight: 60},
+		{Weight: 30, MinGold: 2, MaxGold: 8},
+		{Weight: 10, Table: "consumables"},
+	}})
+	RegisterTable("goblin", &Table{MinRolls: 1, MaxRolls: 2, Entries: []Entry{
+		{Weight: 40},
+		{Weight: 35, MinGold: 5, MaxGold: 15},
+		{Weight: 15, Table: "consumables"},
+		{Weight: 10, Rolled: true},
+	}})
+
+	RegisterTable("elite", &Table{
+		Always:   []Entry{{MinGold: 10, MaxGold: 30}},
+		MinRolls: 1, MaxRolls: 2,
+		Entries: []Entry{
+			{Weight: 50, Rolled: true},
+			{Weight: 35, Table: "consumables"},
+			{Weight: 15, MinGold: 10, MaxGold: 25},
+		},
+	})
+	RegisterTable("boss", &Table{
+		Always:   []Entry{{MinGold: 60, MaxGold: 120}, {Rolled: true}, {Rolled: true}},
+		MinRolls: 2, MaxRolls: 3,
+		Entries: []Entry{
+			{Weight: 50, Table: "consumables"},
+			{Weight: 30, Rolled: true},
+			{Weight: 20, MinGold: 20, MaxGold: 40},
+		},
+	})
+
+	// rooms
+	RegisterTable("room.plain", &Table{Entries: []Entry{
+		{Weight: 70},
+		{Weight: 20, MinGold: 5, MaxGold: 20},
+		{Weight: 10, Table: "consumables"},
+	}})
+	RegisterTable("room.storeroom", &Table{MinRolls: 2, MaxRolls: 4, Entries: []Entry{
+		{Weight: 20},
+		{Weight: 60, Table: "consumables"},
+		{Weight: 20, MinGold: 5, MaxGold: 15},
+	}})
+	RegisterTable("room.treasure", &Table{
+		Always:   []Entry{{MinGold: 20, MaxGold: 50}, {Rolled: true}},
+		MinRolls: 1, MaxRolls: 2,
+		Entries: []Entry{
+			{Weight: 50, Rolled: true},
+			{Weight: 50, Table: "consumables"},
+		},
+	})
+}
@@ -1,100 +1,100 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"image"
 	"image/color"
 	"log"
 	"math"
-	"math/rand/v2"
 	"os"
+	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/inpututil" 
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
 	"github.com/hajimehoshi/ebiten/v2/text"
 	"golang.org/x/image/font"
 	"golang.org/x/image/font/opentype"
 
 	"example.com/go-quest/atlas"
-	"example.com/go-quest/inventory"
+	"example.com/go-quest/enemies"
 	"example.com/go-quest/items"
-	"example.com/go-quest/player"
-	"example.com/go-quest/dungeon"
+	"example.com/go-quest/loot"
 	"example.com/go-quest/rpg"
-	"example.com/go-quest/enemies"
-
+	"example.com/go-quest/world"
 )
 
 /*
 	Top-Down Dungeon Crawler Scaffold (Go + Ebiten)
 
 	What you get:
-	- 32x32 tile world with a simple rooms+corridors generator
+	- 32x32 tile world with rooms+corridors, BSP and cave generators
+	- Headless simulation in package world; Game here is a thin Ebiten adapter
 	- Smooth per-pixel player movement (in player package)
 	- Pixel-based scrolling camera with a dead zone (prevents jitter/bouncing)
 	- Spritesheet support (512x512 sheet, 16x16 cells of 32x32 tiles)
-	- Inventory + items: pickup (E), use (Enter), drop (Q), cycle slots ([ / ])
+	- Inventory + items: pickup (E), use/equip (Enter), drop (Q), cycle slots ([ / ])
+	- Stackable items: split (X) / merge (M) the selected stack
+	- Data-driven items and enemies: JSON definitions in assets/items and
+	  assets/enemies
+	- Random loot: rarity tiers and prefix/suffix affixes, better with depth
+	- Multiple floors: stairs down (.) and up (,); visited floors are kept
+	- Save (F5) / load (F9) to savegame.json
+	- XP from kills, level-ups, character screen (C) to spend points
+	- Timed status effects: buffs, debuffs and damage/heal over time
+	- Fog of war: symmetric shadowcasting FOV, explored tiles remembered dimly
+	- Projectiles: ranged enemies (spitters) keep their distance and shoot
+	- Bosses: every 4th floor, a phased boss fight in a sealed arena
+	- Elite enemies: tinted champions with rolled affixes and extra loot
+	- Spawners: enemy nests, ambush rooms that lock their doors, and an
+	  arena/wave mode (-waves)
 
 	Assets (place in ./assets):
 	- tiles.png   -> 512x512 sheet (16x16 grid). Pick which cells are floor/wall/icons below.
 	- player.png  -> optional 32x32 player sprite (otherwise a blue square is used)
 	- assets/fonts/pixel.ttf -> your pixel TTF
+	- items/*.json -> item definitions (see items.Def)
+	- enemies/*.json -> enemy definitions (see enemies.Def)
 */
 
-type WorldItem struct {
-	ID   string
-	X, Y int // tile coords
-	Inst items.Item
-	Val  int      // NEW: gold amount for ID == "gold"
-}
-
-// Game holds all runtime state.
+// Game is the Ebiten adapter: it owns the window-side state (camera, font,
+// images), turns keys into a world.Input and draws the world.
 type Game struct {
+	// The simulation. Fields are promoted, so g.Tiles, g.Enemies, g.Player,
+	// g.Inv etc. read straight through to the current world state.
+	*world.World
+
 	// Camera position in *pixels* (top-left of the screen in world coords)
 	CamXpx, CamYpx float64
 
-	// Tilemap
-	W, H  int   // map width/height in tiles
-	Tiles []int // len = W*H
-
-	// Graphics
+	// Graphics: sprites by key (items and enemies name theirs)
 	Atlas    *atlas.Atlas
 	imgFloor *ebiten.Image
 	imgWall  *ebiten.Image
 	imgDoor  *ebiten.Image
 	imgWater *ebiten.Image
 
-	// Player
-	Player *player.Player
-
-	// Enimies
-	Enemies []enemies.Enemy
-
 	// Timer (water shimmer, etc.)
 	time float64
 
 	// UI font
 	uiFace font.Face
 
-	// Inventory + world items
-	Inv           *inventory.Inventory
-	ItemsOnGround []WorldItem
-	InvSel        int // selected inventory slot for use/drop (0..)
-	tooltipText   string
-	tooltipTimer  float64
-
+	// Character screen (C): spend attribute points with 1-6
+	charOpen bool
 }
 
-// NewGame creates the world, loads assets, and positions the player.
-func NewGame() *Game {
-	g := &Game{
-		W: 100, // 100x100 tiles of world (feel free to change)
-		H: 100,
-	}
-	g.Tiles = make([]int, g.W*g.H)
+// savePath is where F5 writes and F9 reads.
+const savePath = "savegame.json"
+
+// NewGame loads assets and creates the world for the given seed.
+// The seed drives every random choice, so the same seed rebuilds the same run.
+func NewGame(seed uint64) *Game {
+	g := &Game{}
 
 	// --- Atlas setup ---
-	g.Atlas = atlas.New(TileSize)
+	atl := atlas.New(TileSize)
+	g.Atlas = atl
 
 	// --- UI font (pixel 8-bit look) ---
 	funcMust := func(err error) {
@@ -118,200 +118,75 @@ func NewGame() *Game {
 	}
 
 	// Load main tilesheet (512x512, 16x16 grid)
-	if err := g.Atlas.LoadSheet("tiles", "assets/tiles.png", 16, 16); err != nil {
+	if err := atl.LoadSheet("tiles", "assets/tiles.png", 16, 16); err != nil {
 		log.Printf("tiles.png not found, using fallback colors: %v", err)
 	} else {
 		// Register tiles (change tx,ty to match your sheet)
-		_ = g.Atlas.AddGridTile("floor", "tiles", 0, 0)
-		_ = g.Atlas.AddGridTile("wall", "tiles", 1, 0)
-		_ = g.Atlas.AddGridTile("water", "tiles", 2, 0)
-		_ = g.Atlas.AddGridTile("door", "tiles", 3, 0)
+		_ = atl.AddGridTile("floor", "tiles", 0, 0)
+		_ = atl.AddGridTile("wall", "tiles", 1, 0)
+		_ = atl.AddGridTile("water", "tiles", 2, 0)
+		_ = atl.AddGridTile("door", "tiles", 3, 0)
+		_ = atl.AddGridTile("stairs.down", "tiles", 4, 0)
+		_ = atl.AddGridTile("stairs.up", "tiles", 5, 0)
 
 		// Register item icons (pick any cells you like)
-		_ = g.Atlas.AddGridTile("icon.hp", "tiles", 0, 1)
-		_ = g.Atlas.AddGridTile("icon.boots", "tiles", 1, 1)
+		_ = atl.AddGridTile("icon.hp", "tiles", 0, 1)
+		_ = atl.AddGridTile("icon.boots", "tiles", 1, 1)
+		_ = atl.AddGridTile("icon.sword", "tiles", 3, 1)
 
-		g.imgFloor, _ = g.Atlas.Get("floor")
-		g.imgWall, _ = g.Atlas.Get("wall")
-		g.imgWater, _ = g.Atlas.Get("water")
-		g.imgDoor, _ = g.Atlas.Get("door")
+		g.imgFloor, _ = atl.Get("floor")
+		g.imgWall, _ = atl.Get("wall")
+		g.imgWater, _ = atl.Get("water")
+		g.imgDoor, _ = atl.Get("door")
 
 		// enemies
-		_ = g.Atlas.AddGridTile("enemy.slime", "tiles", 0, 2)
-		_ = g.Atlas.AddGridTile("enemy.goblin", "tiles", 1, 2)
+		_ = atl.AddGridTile("enemy.slime", "tiles", 0, 2)
+		_ = atl.AddGridTile("enemy.goblin", "tiles", 1, 2)
+		_ = atl.AddGridTile("enemy.spitter", "tiles", 0, 2) // a slime, tinted green
 
 		// Gold
-		_ = g.Atlas.AddGridTile("gold", "tiles", 2, 1)
-
-	}
-
-	// Optional: standalone 32x32 player.png (register; ok if missing)
-	_ = g.Atlas.LoadSingle("player", "assets/player.png")
-
-	// Make a dungeon: rooms + L-shaped corridors.
-	g.Tiles = dungeon.Generate(g.W, g.H, TFloor, TWall)
+		_ = atl.AddGridTile("gold", "tiles", 2, 1)
 
-	// Create player from atlas (may be nil → fallback square)
-	var pImg *ebiten.Image
-	if img, ok := g.Atlas.Get("player"); ok {
-		pImg = img
 	}
-	g.Player = player.New(pImg) // default speed is set in player.New()
 
-	// Example mods (if your player package exposes these)
-	g.Player.Mods = []rpg.Modifier{
-		rpg.Flat{Attack: 3, MoveSpeed: 20}, // Leather Boots of Haste
-		rpg.Mult{SpeedMul: 1.10},           // Minor Haste buff (+10%)
+	// Item and food sheets (icons for data-driven items in assets/items)
+	if err := atl.LoadSheet("items", "assets/spritesheet-items.png", 16, 16); err == nil {
+		_ = atl.AddGridTile("icon.cap", "items", 0, 2)
+		_ = atl.AddGridTile("icon.helm", "items", 11, 2)
+		_ = atl.AddGridTile("icon.shield", "items", 2, 3)
+		_ = atl.AddGridTile("icon.ring", "items", 0, 4)
+		_ = atl.AddGridTile("icon.armor", "items", 4, 10)
 	}
-	g.Player.RecomputeStats()
-
-	// Place at first floor tile
-placed:
-	for ty := 0; ty < g.H; ty++ {
-		for tx := 0; tx < g.W; tx++ {
-			if g.at(tx, ty) == TFloor {
-				g.Player.SetPosPixels(float64(tx*TileSize), float64(ty*TileSize))
-				break placed
-			}
-		}
+	if err := atl.LoadSheet("misc", "assets/spritesheet-misc.png", 16, 16); err == nil {
+		_ = atl.AddGridTile("icon.berries", "misc", 0, 0)
+		_ = atl.AddGridTile("icon.strawberry", "misc", 1, 0)
+		_ = atl.AddGridTile("icon.grapes", "misc", 2, 1)
+		_ = atl.AddGridTile("icon.apple", "misc", 13, 1)
 	}
 
-	// Center camera on the player (pixel camera).
-	g.centerCameraOnPlayer()
-
-	// --- Inventory + ground items ---
-	g.Inv = inventory.New(12)
-	// icons already registered above
-	g.spawnDemoNearPlayer()
-	// Sprinkle a few example features (optional)
-	g.placeRandomDoors(8)   // sprinkle a few doors on floor tiles
-	g.paintWaterBlobs(5, 3) // 5 blobs, radius ~3 tiles each
-
-	// --- Inventory + ground items ---
-	g.Inv = inventory.New(12) // 12-slot bag
-
-	// Spawn a couple of demo items on the ground (change coords to somewhere reachable)
-	g.spawnItem("health_potion", 10, 10)
-	g.spawnItem("boots_haste", 14, 12)
-
-	// Enemies
-	ptx := int((g.Player.X + TileSize/2) / TileSize)
-	pty := int((g.Player.Y + TileSize/2) / TileSize)
-	e1 := enemies.New("slime", g.Atlas)
-	e1.SetPos(float64((ptx+3)*TileSize), float64(pty*TileSize))
-	g.Enemies = append(g.Enemies, e1)
-
-	e2 := enemies.New("goblin", g.Atlas)
-	e2.SetPos(float64((ptx+6)*TileSize), float64(pty*TileSize))
-	g.Enemies = append(g.Enemies, e2)
-
-	// spawn some enemies for testing — e.g., 40 random monsters
-	g.spawnEnemiesRandom(40, nil) // nil -> choose from all registered types
-
-	// OR spawn a weighted mix:
-	// g.spawnEnemiesRandom(20, []string{"slime"})
-	// g.spawnEnemiesRandom(10, []string{"goblin"})
-
-
-	return g
-}
-
-func (g *Game) spawnDemoNearPlayer() {
-	ptx := int((g.Player.X + TileSize/2) / TileSize)
-	pty := int((g.Player.Y + TileSize/2) / TileSize)
-
-	// exactly at player tile and one to the right
-	g.spawnItem("health_potion", ptx, pty)
-	g.spawnItem("boots_haste", ptx+1, pty)
-	log.Printf("spawned demo items at (%d,%d) and (%d,%d)", ptx, pty, ptx+1, pty)
-}
-
-func (g *Game) placeRandomDoors(n int) {
-	placed := 0
-	for tries := 0; tries < 500 && placed < n; tries++ {
-		x := 1 + rand.IntN(g.W-2)
-		y := 1 + rand.IntN(g.H-2)
-		if g.at(x, y) != TFloor {
-			continue
-		}
-		// Optional: only place on corridor “choke points”
-		nFloor := 0
-		if g.at(x+1, y) == TFloor {
-			nFloor++
-		}
-		if g.at(x-1, y) == TFloor {
-			nFloor++
-		}
-		if g.at(x, y+1) == TFloor {
-			nFloor++
-		}
-		if g.at(x, y-1) == TFloor {
-			nFloor++
-		}
-		if nFloor < 2 {
-			continue
-		}
-
-		g.set(x, y, TDoor)
-		placed++
+	// Data-driven items and enemies (JSON); Go-coded ones are already registered
+	if err := items.LoadDir("assets/items"); err != nil {
+		log.Printf("item data: %v", err)
 	}
-}
-
-func (g *Game) paintWaterBlobs(count, radius int) {
-	for i := 0; i < count; i++ {
-		cx := 1 + rand.IntN(g.W-2)
-		cy := 1 + rand.IntN(g.H-2)
-		for y := cy - radius; y <= cy+radius; y++ {
-			for x := cx - radius; x <= cx+radius; x++ {
-				if !g.inBounds(x, y) {
-					continue
-				}
-				dx := x - cx
-				dy := y - cy
-				if dx*dx+dy*dy <= radius*radius {
-					if g.at(x, y) == TFloor {
-						g.set(x, y, TWater)
-					}
-				}
-			}
-		}
+	if err := enemies.LoadDir("assets/enemies"); err != nil {
+		// the stock slime and goblin live there; no point starting without them
+		log.Fatalf("enemy data: %v", err)
 	}
-}
 
-// spawnItem creates a world item at tile (tx,ty) using the items registry.
-func (g *Game) spawnItem(id string, tx, ty int) {
-	inst := items.New(id, g.Atlas)
-	if inst == nil {
-		log.Printf("item id %q not registered", id)
-		return
-	}
-	g.ItemsOnGround = append(g.ItemsOnGround, WorldItem{
-		ID: id, X: tx, Y: ty, Inst: inst,
-	})
-}
+	// Optional: standalone 32x32 player.png (register; ok if missing)
+	_ = atl.LoadSingle("player", "assets/player.png")
 
-// spawnGold creates a gold pile at a tile (tx, ty) with a given amount.
-func (g *Game) spawnGold(val, tx, ty int) {
-	// Ensure it's a floor tile before placing
-	if g.at(tx, ty) != TFloor {
-		return
-	}
+	// Build the simulation (first floor, player, demo items and enemies).
+	g.World = world.New(seed)
 
-	// Ensure tile is empty (no items or enemies)
-	if g.tileHasItemOrEnemy(tx, ty) {
-		return
-	}
+	// Center camera on the player (pixel camera).
+	g.centerCameraOnPlayer()
 
-	g.ItemsOnGround = append(g.ItemsOnGround, WorldItem{
-		ID:  "gold",
-		X:   tx,
-		Y:   ty,
-		Val: val,
-	})
+	return g
 }
 
-
-// Update handles input and world updates. Runs ~60x/sec by default.
+// Update reads the keyboard into a world.Input and steps the simulation.
+// Runs ~60x/sec by default.
 func (g *Game) Update() error {
 	// advance time (used for water shimmer etc.)
 	dt := 1.0 / 60.0
@@ -320,198 +195,74 @@ func (g *Game) Update() error {
 	}
 	g.time += dt
 
-	// player movement + collision via callback
-	g.Player.Update(dt, TileSize, func(tx, ty int) bool {
-		if tx < 0 || ty < 0 || tx >= g.W || ty >= g.H {
-			return false
+	// Save (F5) / load (F9)
+	if inpututil.IsKeyJustPressed(ebiten.KeyF5) {
+		msg := "Game saved"
+		if err := g.Save(savePath); err != nil {
+			log.Print(err)
+			msg = "Save failed"
 		}
-		t := g.at(tx, ty)
-		return t != TWall && t != TWater
-	})
-
-	// --- Player Attack ---
-	didAttack := false
-	if inpututil.IsKeyJustPressed(ebiten.KeySpace) && g.Player.CanAttack() {
-		didAttack = true
-		g.Player.DoAttack()
-	}
-
-
-	// Update enemies
-	for i := 0; i < len(g.Enemies); i++ {
-		ee := g.Enemies[i]
-		if !ee.IsAlive() {
-			// remove dead enemy
-			g.Enemies = append(g.Enemies[:i], g.Enemies[i+1:]...)
-			i--
-			continue
-		}
-
-		// update AI
-		ee.Update(dt, g.Player.X, g.Player.Y, func(tx, ty int) bool {
-			if tx < 0 || ty < 0 || tx >= g.W || ty >= g.H {
-				return false
-			}
-			t := g.at(tx, ty)
-			return t != TWall && t != TWater
-		})
-
-		// enemy → player attacks (already implemented)
-		if ee.AttackIfInRange(g.Player.X, g.Player.Y) {
-			dmg := float64(ee.Stats().Attack) * 0.5
-			if dmg <= 0 { dmg = 4 }
-			g.Player.TakeDamage(dmg)
-		}
-
-		// --- NEW: player → enemy attack ---
-		if didAttack {
-			// distance from player to enemy
-			dx := (ee.X() - g.Player.X)
-			dy := (ee.Y() - g.Player.Y)
-			dist := math.Hypot(dx, dy)
-
-			if dist <= g.Player.AttackRangePx() {
-				// deal damage
-				dmg := g.Player.AttackDamage()
-				ee.TakeDamage(dmg)
-
-				// optional: add a knockback or flash here
-			}
+		g.Tooltip, g.TooltipTimer = msg, 1.5
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyF9) {
+		msg := "Game loaded"
+		if err := g.Load(savePath); err != nil {
+			log.Print(err)
+			msg = "Load failed"
 		}
+		g.Tooltip, g.TooltipTimer = msg, 1.5
+		g.centerCameraOnPlayer()
 	}
 
-	// --- Inventory interactions ---
-
-	
-	// 1) Pickup when standing on an item, press E
-// tile player is standing on
-ptx := int((g.Player.X + TileSize/2) / TileSize)
-pty := int((g.Player.Y + TileSize/2) / TileSize)
-
-// --- GOLD AUTO-PICKUP ---
-for i := 0; i < len(g.ItemsOnGround); i++ {
-    wi := g.ItemsOnGround[i]
-    if wi.ID == "gold" && wi.X == ptx && wi.Y == pty {
-        g.Player.Gold += wi.Val
-
-        g.tooltipText = fmt.Sprintf("+%d Gold", wi.Val)
-        g.tooltipTimer = 1.0
+	if inpututil.IsKeyJustPressed(ebiten.KeyC) {
+		g.charOpen = !g.charOpen
+	}
 
-        g.ItemsOnGround = append(g.ItemsOnGround[:i], g.ItemsOnGround[i+1:]...)
-        i--
-    }
-}
+	depth := g.Depth
+	in := readInput()
+	in.Spend = g.readSpend()
+	g.Step(in, dt)
+	if g.Depth != depth {
+		// new floor: snap instead of scrolling across the map
+		g.centerCameraOnPlayer()
+	}
 
-// --- NORMAL ITEM PICKUP (press E) ---
-standingOnItem := false
-for i := range g.ItemsOnGround {
-    wi := g.ItemsOnGround[i]
-
-    // skip gold (already picked up)
-    if wi.ID == "gold" {
-        continue
-    }
-
-    if wi.X == ptx && wi.Y == pty {
-        standingOnItem = true
-        g.tooltipText = fmt.Sprintf("Press [E] to pick up %s", wi.Inst.Name())
-        g.tooltipTimer = 1.0
-
-        if inpututil.IsKeyJustPressed(ebiten.KeyE) {
-            if g.Inv.Add(wi.Inst) {
-                wi.Inst.OnPickup(g.Player)
-                g.ItemsOnGround = append(g.ItemsOnGround[:i], g.ItemsOnGround[i+1:]...)
-            }
-        }
-        break
-    }
-}
+	// camera follows player
+	g.followCamera()
 
-if !standingOnItem && g.tooltipTimer > 0 {
-    g.tooltipTimer -= dt
-    if g.tooltipTimer < 0 {
-        g.tooltipTimer = 0
-        g.tooltipText = ""
-    }
+	return nil
 }
 
+// readInput maps keys to a world.Input for this frame.
+func readInput() world.Input {
+	in := world.Input{}
 
-	standingOnItem = false
-	for i := range g.ItemsOnGround {
-		wi := g.ItemsOnGround[i]
-		if wi.X == ptx && wi.Y == pty {
-			standingOnItem = true
-			g.tooltipText = fmt.Sprintf("Press [E] to pick up %s", wi.Inst.Name())
-			g.tooltipTimer = 1.0 // seconds visible after stepping off
-			if inpututil.IsKeyJustPressed(ebiten.KeyE) {
-				if g.Inv.Add(wi.Inst) {
-					wi.Inst.OnPickup(g.Player)
-					// remove from ground
-					g.ItemsOnGround = append(g.ItemsOnGround[:i], g.ItemsOnGround[i+1:]...)
-				}
-			}
-			break
-		}
-	}
-	if !standingOnItem && g.tooltipTimer > 0 {
-		g.tooltipTimer -= dt
-		if g.tooltipTimer < 0 {
-			g.tooltipTimer = 0
-			g.tooltipText = ""
-		}
-	}
-
-	// 2) Cycle selected slot with [ and ]
-	if inpututil.IsKeyJustPressed(ebiten.KeyLeftBracket) {
-		if g.InvSel > 0 {
-			g.InvSel--
-		}
+	// ---- Movement (held) ----
+	if ebiten.IsKeyPressed(ebiten.KeyA) || ebiten.IsKeyPressed(ebiten.KeyLeft) {
+		in.MoveX -= 1
 	}
-	if inpututil.IsKeyJustPressed(ebiten.KeyRightBracket) {
-		if g.InvSel < g.Inv.Count()-1 {
-			g.InvSel++
-		}
+	if ebiten.IsKeyPressed(ebiten.KeyD) || ebiten.IsKeyPressed(ebiten.KeyRight) {
+		in.MoveX += 1
 	}
-
-	// 3) Use selected item with ENTER
-	if inpututil.IsKeyJustPressed(ebiten.KeyEnter) {
-		it := g.Inv.Get(g.InvSel)
-		if it != nil {
-			if it.OnUse(g.Player) { // consumed?
-				g.Inv.RemoveAt(g.InvSel)
-				if g.InvSel >= g.Inv.Count() {
-					g.InvSel = g.Inv.Count() - 1
-				}
-				if g.InvSel < 0 {
-					g.InvSel = 0
-				}
-			}
-		}
+	if ebiten.IsKeyPressed(ebiten.KeyW) || ebiten.IsKeyPressed(ebiten.KeyUp) {
+		in.MoveY -= 1
 	}
-
-	// 4) Drop selected item with Q
-	if inpututil.IsKeyJustPressed(ebiten.KeyQ) {
-		it := g.Inv.Get(g.InvSel)
-		if it != nil {
-			ptx := int((g.Player.X + TileSize/2) / TileSize)
-			pty := int((g.Player.Y + TileSize/2) / TileSize)
-			if it.OnDrop(g.Player, ptx, pty) {
-				g.spawnItem(it.ID(), ptx, pty)
-				g.Inv.RemoveAt(g.InvSel)
-				if g.InvSel >= g.Inv.Count() {
-					g.InvSel = g.Inv.Count() - 1
-				}
-				if g.InvSel < 0 {
-					g.InvSel = 0
-				}
-			}
-		}
+	if ebiten.IsKeyPressed(ebiten.KeyS) || ebiten.IsKeyPressed(ebiten.KeyDown) {
+		in.MoveY += 1
 	}
 
-	// camera follows player
-	g.followCamera()
-
-	return nil
+	// ---- Actions (just pressed) ----
+	in.Attack = inpututil.IsKeyJustPressed(ebiten.KeySpace)
+	in.Pickup = inpututil.IsKeyJustPressed(ebiten.KeyE)
+	in.Use = inpututil.IsKeyJustPressed(ebiten.KeyEnter)
+	in.Drop = inpututil.IsKeyJustPressed(ebiten.KeyQ)
+	in.PrevSlot = inpututil.IsKeyJustPressed(ebiten.KeyLeftBracket)
+	in.NextSlot = inpututil.IsKeyJustPressed(ebiten.KeyRightBracket)
+	in.Split = inpututil.IsKeyJustPressed(ebiten.KeyX)
+	in.Merge = inpututil.IsKeyJustPressed(ebiten.KeyM)
+	in.Descend = inpututil.IsKeyJustPressed(ebiten.KeyPeriod)
+	in.Ascend = inpututil.IsKeyJustPressed(ebiten.KeyComma)
+	return in
 }
 
 // drawInventoryHelp renders control hints under the inventory bar.
@@ -520,13 +271,13 @@ func (g *Game) drawInventoryHelp(screen *ebiten.Image) {
 		return
 	}
 
-	msg := "[ ]  Cycle  |  ENTER  Use  |  Q  Drop  |  E  Pick Up"
+	msg := "[ ]  Cycle  |  ENTER  Use/Equip  |  Q  Drop  |  E  Pick Up"
 	white := color.NRGBA{230, 230, 240, 255}
 
-	w := len(msg)*6 -16
+	w := len(msg)*6 - 16
 	h := 18
 	x := 8
-	y := ViewH -4 // just under the inventory strip
+	y := ViewH - 4 // just under the inventory strip
 
 	bg := ebiten.NewImage(w, h)
 	bg.Fill(color.NRGBA{0, 0, 0, 140})
@@ -537,38 +288,36 @@ func (g *Game) drawInventoryHelp(screen *ebiten.Image) {
 	text.Draw(screen, msg, g.uiFace, x, y, white)
 }
 
-
 func (g *Game) drawTooltip(screen *ebiten.Image) {
-    if g.tooltipText == "" || g.uiFace == nil {
-        return
-    }
-
-    // fade out over time (0–1)
-    alpha := g.tooltipTimer
-    if alpha > 1 {
-        alpha = 1
-    }
-    if alpha < 0 {
-        alpha = 0
-    }
-
-    // text width estimate
-    msg := g.tooltipText
-    w := len(msg)*6 + 16 // rough width
-    h := 20
-    x := (ViewW - w) / 2
-    y := ViewH - 80
-
-    bg := ebiten.NewImage(w, h)
-    bg.Fill(color.NRGBA{0, 0, 0, uint8(180 * alpha)})
-    op := &ebiten.DrawImageOptions{}
-    op.GeoM.Translate(float64(x), float64(y))
-    screen.DrawImage(bg, op)
-
-    white := color.NRGBA{255, 255, 255, uint8(255 * alpha)}
-    text.Draw(screen, msg, g.uiFace, x+8, y+14, white)
-}
+	if g.Tooltip == "" || g.uiFace == nil {
+		return
+	}
 
+	// fade out over time (0–1)
+	alpha := g.TooltipTimer
+	if alpha > 1 {
+		alpha = 1
+	}
+	if alpha < 0 {
+		alpha = 0
+	}
+
+	// text width estimate
+	msg := g.Tooltip
+	w := len(msg)*6 + 16 // rough width
+	h := 20
+	x := (ViewW - w) / 2
+	y := ViewH - 80
+
+	bg := ebiten.NewImage(w, h)
+	bg.Fill(color.NRGBA{0, 0, 0, uint8(180 * alpha)})
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Translate(float64(x), float64(y))
+	screen.DrawImage(bg, op)
+
+	white := color.NRGBA{255, 255, 255, uint8(255 * alpha)}
+	text.Draw(screen, msg, g.uiFace, x+8, y+14, white)
+}
 
 // === UI: Stats panel (top-right) ===
 func (g *Game) drawStatsPanel(screen *ebiten.Image) {
@@ -579,7 +328,7 @@ func (g *Game) drawStatsPanel(screen *ebiten.Image) {
 
 	const pad = 8
 	panelW := 190
-	panelH := 190
+	panelH := 204
 	x := ViewW - panelW - pad
 	y := pad
 
@@ -596,7 +345,6 @@ func (g *Game) drawStatsPanel(screen *ebiten.Image) {
 	gray := color.NRGBA{180, 180, 200, 255}
 	goldColor := color.NRGBA{255, 215, 0, 255} // bright gold/yellow
 
-
 	// Title
 	text.Draw(screen, "PLAYER", g.uiFace, tx, ty, white)
 	ty += 6
@@ -665,6 +413,28 @@ func (g *Game) drawStatsPanel(screen *ebiten.Image) {
 	// GOLD
 	ty += 14
 	text.Draw(screen, fmt.Sprintf("GOLD %d", p.Gold), g.uiFace, tx, ty, goldColor)
+
+	// LEVEL / XP
+	ty += 14
+	lvl := fmt.Sprintf("LV %d  XP %d/%d", p.Attr.Level, p.Attr.XP, rpg.XPToNext(p.Attr.Level))
+	if p.Attr.Unspent > 0 {
+		lvl += fmt.Sprintf("  +%d", p.Attr.Unspent)
+	}
+	text.Draw(screen, lvl, g.uiFace, tx, ty, white)
+
+	// Active status effects, listed under the panel
+	ty = y + panelH + 14
+	for _, fx := range p.Effects.Active() {
+		label := fx.Name
+		if fx.Stacks > 1 {
+			label += fmt.Sprintf(" x%d", fx.Stacks)
+		}
+		if fx.Duration > 0 {
+			label += fmt.Sprintf(" %.0fs", math.Ceil(fx.Remaining))
+		}
+		text.Draw(screen, label, g.uiFace, tx, ty, gray)
+		ty += 14
+	}
 }
 
 // === UI: Inventory strip (bottom-left) ===
@@ -687,16 +457,27 @@ func (g *Game) drawInventory(screen *ebiten.Image) {
 
 		// slot border
 		slot := ebiten.NewImage(slotSize-4, slotSize-4)
-		slot.Fill(color.NRGBA{50, 50, 60, 255})
+		slot.Fill(slotColor(it))
 		op := &ebiten.DrawImageOptions{}
 		op.GeoM.Translate(float64(x), float64(y))
 		screen.DrawImage(slot, op)
 
 		// icon
-		if it != nil && it.Icon() != nil {
+		if img := g.icon(it); img != nil {
 			op2 := &ebiten.DrawImageOptions{}
 			op2.GeoM.Translate(float64(x+2), float64(y+2))
-			screen.DrawImage(it.Icon(), op2)
+			screen.DrawImage(img, op2)
+		}
+
+		// stack size in the bottom-right corner
+		if n := g.Inv.Qty(i); n > 1 {
+			q := fmt.Sprint(n)
+			text.Draw(screen, q, g.uiFace, x+slotSize-6-6*len(q), y+slotSize-6, color.White)
+		}
+
+		// worn equipment gets a gold "E" in the corner
+		if eq, ok := it.(items.Equipment); ok && g.Player.IsEquipped(eq) {
+			text.Draw(screen, "E", g.uiFace, x+2, y+11, color.NRGBA{255, 215, 0, 255})
 		}
 
 		// selection highlight
@@ -710,6 +491,24 @@ func (g *Game) drawInventory(screen *ebiten.Image) {
 	}
 }
 
+// slotColor is the inventory slot background: plain, or tinted by the
+// rarity of rolled loot.
+func slotColor(it items.Item) color.NRGBA {
+	l, ok := it.(*loot.Item)
+	if !ok {
+		return color.NRGBA{50, 50, 60, 255}
+	}
+	switch l.Rarity() {
+	case loot.Magic:
+		return color.NRGBA{40, 60, 130, 255}
+	case loot.Rare:
+		return color.NRGBA{120, 110, 30, 255}
+	case loot.Unique:
+		return color.NRGBA{140, 80, 20, 255}
+	}
+	return color.NRGBA{50, 50, 60, 255}
+}
+
 // drawBar draws a simple filled bar (background + foreground by percentage).
 func drawBar(screen *ebiten.Image, x, y, w, h int, pct float64, fg, bg color.Color) {
 	if w <= 0 || h <= 0 {
@@ -753,153 +552,6 @@ func clamp01(v float64) float64 {
 // Layout fixes the logical resolution of the window (Ebiten will scale as needed).
 func (g *Game) Layout(ow, oh int) (int, int) { return ViewW, ViewH }
 
-
-// pick a random floor tile and spawn enemies there.
-func (g *Game) spawnEnemiesRandom(n int, allowedTypes []string) {
-    if n <= 0 {
-        return
-    }
-
-    // build list of candidate floor tile coords
-    candidates := make([]struct{ x, y int }, 0, 1024)
-    for y := 0; y < g.H; y++ {
-        for x := 0; x < g.W; x++ {
-            if g.at(x, y) == TFloor {
-                // avoid spawning on player tile
-                px := int((g.Player.X + TileSize/2) / TileSize)
-                py := int((g.Player.Y + TileSize/2) / TileSize)
-                if x == px && y == py {
-                    continue
-                }
-                // skip tiles that already hold an item or enemy
-                if g.tileHasItemOrEnemy(x, y) {
-                    continue
-                }
-                candidates = append(candidates, struct{ x, y int }{x, y})
-            }
-        }
-    }
-
-    if len(candidates) == 0 {
-        return
-    }
-
-    // fallback: if no allowedTypes provided, choose from registry
-    types := allowedTypes
-    if len(types) == 0 {
-        types = enemies.AllIDs()
-    }
-    if len(types) == 0 {
-        log.Println("no enemy types registered")
-        return
-    }
-
-	for i := 0; i < n && len(candidates) > 0; i++ {
-
-		// pick a candidate tile
-		idx := rand.IntN(len(candidates))
-		c := candidates[idx]
-
-		// pick random enemy type
-		et := types[rand.IntN(len(types))]
-		e := enemies.New(et, g.Atlas)
-		if e == nil {
-			// if enemy type is not registered, skip
-			// but don’t crash
-			continue
-		}
-
-		// center enemy on tile (px, py)
-		px := float64(c.x*TileSize + TileSize/2)
-		py := float64(c.y*TileSize + TileSize/2)
-		e.SetPos(px, py)
-
-		g.Enemies = append(g.Enemies, e)
-
-		// remove tile from candidate list so we don’t spawn duplicates there
-		candidates[idx] = candidates[len(candidates)-1]
-		candidates = candidates[:len(candidates)-1]
-	}
-
-}
-
-
-// spawnGoldRandom scatters `count` gold piles across the dungeon.
-// minVal..maxVal defines the random amount per pile (inclusive).
-// It avoids walls, tiles that already hold an item/enemy, and tiles too close to the player.
-func (g *Game) spawnGoldRandom(count, minVal, maxVal int) {
-	if count <= 0 || maxVal < minVal {
-		return
-	}
-
-	placed := 0
-	tries := 0
-	maxTries := count * 30 // safety cap to avoid infinite loops
-
-	px := int((g.Player.X + TileSize/2) / TileSize)
-	py := int((g.Player.Y + TileSize/2) / TileSize)
-
-	for placed < count && tries < maxTries {
-		tries++
-		// pick random interior tile (avoid edges slightly)
-		x := 1 + rand.IntN(g.W-2)
-		y := 1 + rand.IntN(g.H-2)
-
-		// must be walkable floor
-		if g.at(x, y) != TFloor {
-			continue
-		}
-		// avoid player vicinity (2 tiles radius)
-		if abs(x-px) <= 2 && abs(y-py) <= 2 {
-			continue
-		}
-		// avoid tiles that already have an item or enemy
-		if g.tileHasItemOrEnemy(x, y) {
-			continue
-		}
-
-		// pick value
-		val := minVal
-		if maxVal > minVal {
-			val = minVal + rand.IntN(maxVal-minVal+1)
-		}
-
-		g.ItemsOnGround = append(g.ItemsOnGround, WorldItem{
-			ID:  "gold",
-			X:   x,
-			Y:   y,
-			Inst: nil,
-			Val: val,
-		})
-		placed++
-	}
-
-	// optional debug log
-	// log.Printf("spawnGoldRandom placed %d gold piles (%d tries)\n", placed, tries)
-}
-
-
-// helper: check if a tile already contains an item or enemy
-func (g *Game) tileHasItemOrEnemy(tx, ty int) bool {
-    // item check
-    for _, it := range g.ItemsOnGround {
-        if it.X == tx && it.Y == ty {
-            return true
-        }
-    }
-    // enemy check: convert enemy pixel pos to tile coords
-    for _, e := range g.Enemies {
-        ex := int((e.X()) / TileSize)
-        ey := int((e.Y()) / TileSize)
-        if ex == tx && ey == ty {
-            return true
-        }
-    }
-    // you can also check player here, but spawnEnemiesRandom already avoids player tile
-    return false
-}
-
-
 /* =========================
    Misc helpers
    ========================= */
@@ -909,18 +561,28 @@ func spriteRect(tx, ty int) image.Rectangle {
 	return image.Rect(tx*TileSize, ty*TileSize, (tx+1)*TileSize, (ty+1)*TileSize)
 }
 
-func abs(a int) int { if a < 0 { return -a }; return a }
-
-
 /* =========================
    Entry point
    ========================= */
 
 func main() {
+	seed := flag.Uint64("seed", 0, "dungeon seed (0 = random)")
+	waves := flag.Bool("waves", false, "arena mode: survive escalating waves in an open pit")
+	flag.Parse()
+	if *seed == 0 {
+		*seed = uint64(time.Now().UnixNano())
+	}
+	log.Printf("seed %d", *seed)
+
 	ebiten.SetWindowSize(ViewW, ViewH)
 	ebiten.SetWindowTitle("Go Quest")
 
-	if err := ebiten.RunGame(NewGame()); err != nil {
+	g := NewGame(*seed)
+	if *waves {
+		g.StartWaves()
+		g.centerCameraOnPlayer()
+	}
+	if err := ebiten.RunGame(g); err != nil {
 		log.Fatal(err)
 	}
 }
@@ -0,0 +1,27 @@
+package move
+
+// Slide moves a sprite whose top-left pixel is (x,y) by (dx,dy) and returns
+// the new position. X and Y are resolved separately, so a blocked diagonal
+// move keeps the free axis and the mover slides along walls instead of
+// sticking. A position is free when the tile under the sprite's center is
+// passable (the same rule the player has always used).
+func Slide(x, y, dx, dy float64, tileSize int, passable func(tx, ty int) bool) (float64, float64) {
+	if dx != 0 && free(x+dx, y, tileSize, passable) {
+		x += dx
+	}
+	if dy != 0 && free(x, y+dy, tileSize, passable) {
+		y += dy
+	}
+	return x, y
+}
+
+// Tile returns the tile under the center of a sprite at pixel (x,y).
+func Tile(x, y float64, tileSize int) (int, int) {
+	ts := float64(tileSize)
+	return int((x + ts/2) / ts), int((y + ts/2) / ts)
+}
+
+func free(x, y float64, tileSize int, passable func(tx, ty int) bool) bool {
+	tx, ty := Tile(x, y, tileSize)
+	return passable(tx, ty)
+}
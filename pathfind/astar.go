@@ -0,0 +1,122 @@
+package pathfind
+
+import (
+	"container/heap"
+	"image"
+	"math"
+)
+
+// Passable reports whether tile (tx,ty) can be walked on.
+// It is the same callback the game hands to the player and enemies.
+type Passable = func(tx, ty int) bool
+
+// DefaultMaxNodes caps how many tiles one search may expand before giving up.
+const DefaultMaxNodes = 2000
+
+// Find runs A* on the tile grid from (sx,sy) to (gx,gy).
+// Diagonal steps are allowed only when both orthogonal neighbours are
+// passable, so paths never cut wall corners. The returned path excludes the
+// start and ends at the goal; it is nil when the goal is unreachable or the
+// search expands more than maxNodes tiles (<= 0 means DefaultMaxNodes).
+func Find(sx, sy, gx, gy int, passable Passable, maxNodes int) []image.Point {
+	if maxNodes <= 0 {
+		maxNodes = DefaultMaxNodes
+	}
+	start, goal := image.Pt(sx, sy), image.Pt(gx, gy)
+	if start == goal {
+		return []image.Point{}
+	}
+	if !passable(gx, gy) {
+		return nil
+	}
+
+	open := &nodeHeap{}
+	came := map[image.Point]image.Point{}
+	cost := map[image.Point]float64{start: 0}
+	closed := map[image.Point]bool{}
+	heap.Push(open, node{p: start, f: octile(start, goal)})
+
+	for open.Len() > 0 && len(closed) < maxNodes {
+		cur := heap.Pop(open).(node).p
+		if cur == goal {
+			return rebuild(came, start, goal)
+		}
+		if closed[cur] {
+			continue
+		}
+		closed[cur] = true
+
+		for _, d := range dirs8 {
+			n := cur.Add(d)
+			if closed[n] || !passable(n.X, n.Y) {
+				continue
+			}
+			step := 1.0
+			if d.X != 0 && d.Y != 0 {
+				// no corner cutting: both sides of the diagonal must be open
+				if !passable(cur.X+d.X, cur.Y) || !passable(cur.X, cur.Y+d.Y) {
+					continue
+				}
+				step = math.Sqrt2
+			}
+			g := cost[cur] + step
+			if old, ok := cost[n]; ok && g >= old {
+				continue
+			}
+			cost[n] = g
+			came[n] = cur
+			heap.Push(open, node{p: n, g: g, f: g + octile(n, goal)})
+		}
+	}
+	return nil
+}
+
+func rebuild(came map[image.Point]image.Point, start, goal image.Point) []image.Point {
+	var path []image.Point
+	for p := goal; p != start; p = came[p] {
+		path = append(path, p)
+	}
+	// reverse to start→goal order
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
+
+// octile is the exact distance on an 8-way grid with no obstacles.
+func octile(a, b image.Point) float64 {
+	dx := math.Abs(float64(a.X - b.X))
+	dy := math.Abs(float64(a.Y - b.Y))
+	return math.Max(dx, dy) + (math.Sqrt2-1)*math.Min(dx, dy)
+}
+
+// Orthogonal neighbours first, so ties prefer straight moves.
+var dirs8 = [8]image.Point{
+	{1, 0}, {-1, 0}, {0, 1}, {0, -1},
+	{1, 1}, {1, -1}, {-1, 1}, {-1, -1},
+}
+
+/* ---------- priority queue ---------- */
+
+type node struct {
+	p    image.Point
+	g, f float64
+}
+
+type nodeHeap []node
+
+func (h nodeHeap) Len() int { return len(h) }
+func (h nodeHeap) Less(i, j int) bool {
+	if h[i].f != h[j].f {
+		return h[i].f < h[j].f
+	}
+	return h[i].g > h[j].g // prefer nodes closer to the goal on ties
+}
+func (h nodeHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+func (h *nodeHeap) Push(x any)   { *h = append(*h, x.(node)) }
+func (h *nodeHeap) Pop() any {
+	old := *h
+	n := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return n
+}
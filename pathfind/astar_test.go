@@ -0,0 +1,157 @@
+package pathfind
+
+import (
+	"image"
+	"testing"
+)
+
+// grid turns rows of text into a Passable: '#' is a wall, anything else
+// is floor, and everything outside the rows is wall.
+func grid(rows ...string) Passable {
+	return func(tx, ty int) bool {
+		if ty < 0 || ty >= len(rows) || tx < 0 || tx >= len(rows[ty]) {
+			return false
+		}
+		return rows[ty][tx] != '#'
+	}
+}
+
+// open is an unbounded floor.
+func open(tx, ty int) bool { return true }
+
+func TestFind(t *testing.T) {
+	tests := []struct {
+		name     string
+		passable Passable
+		sx, sy   int
+		gx, gy   int
+		maxNodes int
+		want     int // path length; -1 = no path
+	}{
+		{"same tile", open, 3, 3, 3, 3, 0, 0},
+		{"straight", open, 0, 0, 5, 0, 0, 5},
+		{"diagonal", open, 0, 0, 4, 4, 0, 4},
+		{
+			"around a wall",
+			grid(
+				"..#..",
+				"..#..",
+				".....",
+			),
+			0, 0, 4, 0, 0, 6,
+		},
+		{
+			// the diagonal (0,0)->(1,1) squeezes between two walls
+			"no corner cutting",
+			grid(
+				".#",
+				"#.",
+			),
+			0, 0, 1, 1, 0, -1,
+		},
+		{
+			// one wall beside the diagonal still blocks it: go around
+			"no cutting one corner",
+			grid(
+				".#",
+				"..",
+			),
+			0, 0, 1, 1, 0, 2,
+		},
+		{
+			"unreachable goal",
+			grid(
+				"..#..",
+				"..#..",
+				"..#..",
+			),
+			0, 1, 4, 1, 0, -1,
+		},
+		{"goal is a wall", grid("..#"), 0, 0, 2, 0, 0, -1},
+		{"over maxNodes", open, 0, 0, 40, 0, 10, -1},
+		{"under maxNodes", open, 0, 0, 40, 0, 100, 40},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := Find(tt.sx, tt.sy, tt.gx, tt.gy, tt.passable, tt.maxNodes)
+			if tt.want < 0 {
+				if path != nil {
+					t.Fatalf("got path %v, want none", path)
+				}
+				return
+			}
+			if path == nil || len(path) != tt.want {
+				t.Fatalf("got path %v (len %d), want len %d", path, len(path), tt.want)
+			}
+			checkPath(t, path, tt.sx, tt.sy, tt.gx, tt.gy, tt.passable)
+		})
+	}
+}
+
+// checkPath verifies a path walks from the start to the goal one passable
+// tile at a time without cutting corners.
+func checkPath(t *testing.T, path []image.Point, sx, sy, gx, gy int, passable Passable) {
+	t.Helper()
+	if len(path) == 0 {
+		return
+	}
+	if last := path[len(path)-1]; last != image.Pt(gx, gy) {
+		t.Fatalf("path ends at %v, want (%d,%d)", last, gx, gy)
+	}
+	prev := image.Pt(sx, sy)
+	for _, p := range path {
+		d := p.Sub(prev)
+		if !adjacent(p, prev) || d == (image.Point{}) {
+			t.Fatalf("step %v -> %v is not one tile", prev, p)
+		}
+		if !passable(p.X, p.Y) {
+			t.Fatalf("path crosses wall at %v", p)
+		}
+		if d.X != 0 && d.Y != 0 && (!passable(prev.X+d.X, prev.Y) || !passable(prev.X, prev.Y+d.Y)) {
+			t.Fatalf("step %v -> %v cuts a corner", prev, p)
+		}
+		prev = p
+	}
+}
+
+func TestCacheRecomputesOnGoalChange(t *testing.T) {
+	searches := 0
+	counting := func(tx, ty int) bool {
+		searches++
+		return open(tx, ty)
+	}
+	var c Cache
+
+	next, ok := c.Next(0.1, 0, 0, 5, 0, counting)
+	if !ok || next != image.Pt(1, 0) {
+		t.Fatalf("first step = %v, %v; want (1,0), true", next, ok)
+	}
+
+	// same goal, same spot: the cached path is reused
+	n := searches
+	if next, ok = c.Next(0.1, 0, 0, 5, 0, counting); !ok || next != image.Pt(1, 0) {
+		t.Fatalf("cached step = %v, %v; want (1,0), true", next, ok)
+	}
+	if searches != n {
+		t.Fatalf("searched again for the same goal")
+	}
+
+	// the goal moves to another tile: search again and head for it
+	if next, ok = c.Next(0.1, 0, 0, 0, 5, counting); !ok || next != image.Pt(0, 1) {
+		t.Fatalf("step after goal moved = %v, %v; want (0,1), true", next, ok)
+	}
+	if searches == n {
+		t.Fatalf("didn't search again after the goal moved")
+	}
+}
+
+func TestCacheUnreachable(t *testing.T) {
+	walled := grid(
+		"..#..",
+		"..#..",
+	)
+	var c Cache
+	if next, ok := c.Next(0.1, 0, 0, 4, 0, walled); ok || next != image.Pt(0, 0) {
+		t.Fatalf("step toward walled-off goal = %v, %v; want (0,0), false", next, ok)
+	}
+}
@@ -0,0 +1,61 @@
+package pathfind
+
+import "image"
+
+// Cache remembers one agent's current path so it doesn't run A* every
+// frame. The path is reused while the goal tile is unchanged and the agent
+// is still on it; it is recomputed when the goal moves, the agent strays,
+// or MaxAge seconds have passed (doors and water can change the map).
+type Cache struct {
+	MaxAge float64 // seconds before a path is recomputed anyway; 0 = 1s
+
+	path []image.Point
+	goal image.Point
+	age  float64
+	ok   bool // path is valid for goal
+	none bool // last search found no path to goal
+}
+
+// Next returns the next tile to step onto from (tx,ty) toward (gx,gy).
+// found is false when no path exists; an agent should give up the chase.
+// When the agent is already on the goal tile, Next returns the goal itself.
+func (c *Cache) Next(dt float64, tx, ty, gx, gy int, passable Passable) (next image.Point, found bool) {
+	maxAge := c.MaxAge
+	if maxAge <= 0 {
+		maxAge = 1
+	}
+	c.age += dt
+
+	cur, goal := image.Pt(tx, ty), image.Pt(gx, gy)
+	if cur == goal {
+		return goal, true
+	}
+
+	// drop waypoints we've reached
+	for len(c.path) > 0 && c.path[0] == cur {
+		c.path = c.path[1:]
+	}
+
+	fresh := c.ok && c.goal == goal && c.age < maxAge
+	if fresh && c.none {
+		return cur, false // still unreachable; don't search again yet
+	}
+	if !fresh || len(c.path) == 0 || !adjacent(cur, c.path[0]) {
+		c.path = Find(tx, ty, gx, gy, passable, 0)
+		c.goal, c.age = goal, 0
+		c.ok = true
+		c.none = c.path == nil
+	}
+	if c.none || len(c.path) == 0 {
+		return cur, false
+	}
+	return c.path[0], true
+}
+
+// Reset forgets the cached path (e.g., after a teleport or level change).
+func (c *Cache) Reset() { *c = Cache{MaxAge: c.MaxAge} }
+
+func adjacent(a, b image.Point) bool {
+	dx, dy := a.X-b.X, a.Y-b.Y
+	return dx >= -1 && dx <= 1 && dy >= -1 && dy <= 1
+}
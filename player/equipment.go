@@ -0,0 +1,84 @@
+package player
+
+import "example.com/go-quest/rpg"
+
+// Slot is where a piece of equipment is worn.
+type Slot int
+
+const (
+	SlotHead Slot = iota
+	SlotBody
+	SlotFeet
+	SlotWeapon
+	SlotOffhand
+	SlotRing
+
+	NumSlots // number of slots; not a slot
+)
+
+func (s Slot) String() string {
+	switch s {
+	case SlotHead:
+		return "head"
+	case SlotBody:
+		return "body"
+	case SlotFeet:
+		return "feet"
+	case SlotWeapon:
+		return "weapon"
+	case SlotOffhand:
+		return "offhand"
+	case SlotRing:
+		return "ring"
+	}
+	return "?"
+}
+
+// Gear is anything the player can wear. Equipment items implement it; the
+// player only needs the slot and the modifiers it grants.
+type Gear interface {
+	Slot() Slot
+	Mods() []rpg.Modifier
+}
+
+// Equip wears g in its slot and returns what was there before (or nil).
+// Mods and stats are rebuilt.
+func (p *Player) Equip(g Gear) Gear {
+	s := g.Slot()
+	if s < 0 || s >= NumSlots {
+		return nil
+	}
+	prev := p.Gear[s]
+	p.Gear[s] = g
+	p.rebuildMods()
+	return prev
+}
+
+// Unequip empties slot s and returns what was worn there (or nil).
+func (p *Player) Unequip(s Slot) Gear {
+	if s < 0 || s >= NumSlots || p.Gear[s] == nil {
+		return nil
+	}
+	prev := p.Gear[s]
+	p.Gear[s] = nil
+	p.rebuildMods()
+	return prev
+}
+
+// IsEquipped reports whether this exact piece of gear is being worn.
+func (p *Player) IsEquipped(g Gear) bool {
+	s := g.Slot()
+	return s >= 0 && s < NumSlots && p.Gear[s] == g
+}
+
+// rebuildMods sets Mods to the modifiers of everything worn, in slot
+// order, and recomputes stats.
+func (p *Player) rebuildMods() {
+	p.Mods = nil
+	for _, g := range p.Gear {
+		if g != nil {
+			p.Mods = append(p.Mods, g.Mods()...)
+		}
+	}
+	p.RecomputeStats()
+}
@@ -1,38 +1,38 @@
 package player
 
 import (
-	"image/color"
-	"math"
-
-	"github.com/hajimehoshi/ebiten/v2"
+	"example.com/go-quest/move"
 	"example.com/go-quest/rpg"
 )
 
-// Player holds position (in pixels), movement speed, and an optional sprite.
+// Player holds position (in pixels), movement speed and RPG state. The
+// game draws it (atlas key "player"); the package never touches graphics.
 type Player struct {
-	X, Y  float64       // pixel position (top-left of the 32x32 sprite)
-	Speed float64       // pixels per second
-	Img   *ebiten.Image // optional 32x32 image; if nil we draw a blue square
-	time  float64       // local timer (for simple effects if you want)
+	X, Y  float64 // pixel position (top-left of the 32x32 sprite)
+	Speed float64 // pixels per second
+	time  float64 // local timer (for simple effects if you want)
 
 	Attr  rpg.Attributes
 	Stats rpg.Stats
-	Mods  []rpg.Modifier // equipment/buffs currently applied
+	Mods  []rpg.Modifier // from worn gear; rebuilt by Equip/Unequip
+
+	Gear [NumSlots]Gear // worn equipment, by slot (nil = empty)
+
+	Effects rpg.Effects // timed buffs, debuffs and DoTs
 
 	// Stamina recovery behaviour
 	stamRecoverDelay float64 // seconds to wait after moving before regen
 	stamRecoverTimer float64 // counts down to 0, then regen resumes
 
-	attackTimer float64
+	attackTimer    float64
 	attackCooldown float64
 
 	Gold int
 }
 
-// New creates a player with a given sprite (can be nil). Speed is px/s.
-func New(img *ebiten.Image) *Player {
+// New creates a level 1 player. Speed is px/s, driven by Stats.
+func New() *Player {
 	p := &Player{
-		Img: img,
 		Attr: rpg.Attributes{
 			Level: 1, Str: 4, Dex: 6, Int: 3, Vit: 5, Wis: 3, Lck: 2,
 		},
@@ -40,13 +40,22 @@ func New(img *ebiten.Image) *Player {
 	// Initial compute
 	p.RecomputeStats()
 	p.stamRecoverDelay = 0.6 // ~600ms feels good
-	p.attackCooldown = 0.4 // 400 ms per swing
+	p.attackCooldown = 0.4   // 400 ms per swing
 	return p
 }
 
 // RecomputeStats recalculates Stats from Attr and Mods, and syncs movement speed.
+// Current HP/MP/stamina carry over (clamped to the new caps); only the very
+// first compute starts the player full.
 func (p *Player) RecomputeStats() {
-	p.Stats = rpg.Recompute(p.Attr, p.Mods...)
+	cur, first := p.Stats, p.Stats.HPMax == 0
+	mods := append(append([]rpg.Modifier{}, p.Mods...), p.Effects.Mods()...)
+	p.Stats = rpg.Recompute(p.Attr, mods...)
+	if !first {
+		p.Stats.HP = min(cur.HP, float64(p.Stats.HPMax))
+		p.Stats.MP = min(cur.MP, float64(p.Stats.MPMax))
+		p.Stats.Stamina = min(cur.Stamina, float64(p.Stats.StaminaMax))
+	}
 	// Drive movement speed from Stats (so boots, buffs affect speed)
 	p.Speed = p.Stats.MoveSpeed
 }
@@ -82,26 +91,17 @@ func (p *Player) SetPosPixels(x, y float64) {
 	p.X, p.Y = x, y
 }
 
-// Update reads input and attempts to move the player.
+// Update attempts to move the player along the input direction.
 // - dt: seconds since last frame
 // - tileSize: e.g., 32
+// - ax, ay: movement input, -1..1 each (the caller reads the keyboard)
 // - passable(tx,ty): returns true if that tile is walkable
-func (p *Player) Update(dt float64, tileSize int, passable func(tx, ty int) bool) {
+func (p *Player) Update(dt float64, tileSize int, ax, ay float64, passable func(tx, ty int) bool) {
 	p.time += dt
 
-	// ---- Movement input ----
-	ax, ay := 0.0, 0.0
-	if ebiten.IsKeyPressed(ebiten.KeyA) || ebiten.IsKeyPressed(ebiten.KeyLeft) {
-		ax -= 1
-	}
-	if ebiten.IsKeyPressed(ebiten.KeyD) || ebiten.IsKeyPressed(ebiten.KeyRight) {
-		ax += 1
-	}
-	if ebiten.IsKeyPressed(ebiten.KeyW) || ebiten.IsKeyPressed(ebiten.KeyUp) {
-		ay -= 1
-	}
-	if ebiten.IsKeyPressed(ebiten.KeyS) || ebiten.IsKeyPressed(ebiten.KeyDown) {
-		ay += 1
+	// Status effects tick (poison, regen...); expiry changes stats
+	if p.Effects.Update(dt, &p.Stats) {
+		p.RecomputeStats()
 	}
 
 	// Compute stamina-scaled speed (0 at zero stamina)
@@ -122,12 +122,10 @@ func (p *Player) Update(dt float64, tileSize int, passable func(tx, ty int) bool
 		dx := ax * speed * dt
 		dy := ay * speed * dt
 
-		nx := p.X + dx
-		ny := p.Y + dy
-		tx := int((nx + float64(tileSize)/2) / float64(tileSize))
-		ty := int((ny + float64(tileSize)/2) / float64(tileSize))
+		// axis-separated, so we slide along walls instead of stopping dead
+		nx, ny := move.Slide(p.X, p.Y, dx, dy, tileSize, passable)
 
-		if passable(tx, ty) {
+		if nx != p.X || ny != p.Y {
 			p.X, p.Y = nx, ny
 
 			// Stamina drain while moving
@@ -165,31 +163,6 @@ func (p *Player) Update(dt float64, tileSize int, passable func(tx, ty int) bool
 	}
 }
 
-// Draw renders the player at (X,Y) relative to the camera (camX,camY).
-// If Img is nil, draws a blue 32x32 square as a fallback.
-func (p *Player) Draw(screen *ebiten.Image, camX, camY float64, tileSize int) {
-	op := &ebiten.DrawImageOptions{}
-	op.GeoM.Translate(p.X-camX, p.Y-camY)
-
-	if p.Img != nil {
-		screen.DrawImage(p.Img, op)
-		return
-	}
-
-	// Fallback: blue square with a tiny breathing effect
-	img := ebiten.NewImage(tileSize, tileSize)
-	// subtle brightness pulse
-	b := 0.90 + 0.10*math.Sin(p.time*3.2)
-	col := color.NRGBA{R: uint8(0x6b), G: uint8(0xc1), B: uint8(0xff), A: 0xff}
-	img.Fill(color.NRGBA{
-		R: uint8(float64(col.R) * b),
-		G: uint8(float64(col.G) * b),
-		B: uint8(float64(col.B) * b),
-		A: col.A,
-	})
-	screen.DrawImage(img, op)
-}
-
 // TakeDamage applies damage to the player and clamps HP to zero.
 func (p *Player) TakeDamage(d float64) {
 	p.Stats.HP -= d
@@ -203,17 +176,38 @@ func (p *Player) IsAlive() bool {
 	return p.Stats.HP > 0
 }
 
-// AttackDamage returns how much damage the player deals.
-// You can mix in STR, DEX, weapons, etc.
-func (p *Player) AttackDamage() float64 {
-	return float64(p.Stats.Attack)
-}
-
 // AttackRangePx is the melee reach in pixels.
 func (p *Player) AttackRangePx() float64 {
 	return 24 // one tile edge = 32px, so 24 feels good
 }
 
+// AddEffect applies a timed status effect and recomputes stats if its
+// modifiers changed anything.
+func (p *Player) AddEffect(e rpg.Effect) {
+	if p.Effects.Apply(e) {
+		p.RecomputeStats()
+	}
+}
+
+// GainXP adds experience. Level-ups bank their points in Attr.Unspent and
+// recompute stats; it returns the number of levels gained.
+func (p *Player) GainXP(n int) int {
+	lv := p.Attr.GainXP(n)
+	if lv > 0 {
+		p.RecomputeStats()
+	}
+	return lv
+}
+
+// SpendPoint puts one unspent point into attribute id and recomputes stats.
+func (p *Player) SpendPoint(id rpg.AttrID) bool {
+	if !p.Attr.Spend(id) {
+		return false
+	}
+	p.RecomputeStats()
+	return true
+}
+
 func (p *Player) CanAttack() bool {
 	return p.attackTimer <= 0
 }
@@ -221,5 +215,3 @@ func (p *Player) CanAttack() bool {
 func (p *Player) DoAttack() {
 	p.attackTimer = p.attackCooldown
 }
-
-
@@ -0,0 +1,95 @@
+// Package projectile flies shots (arrows, spit, bolts) across a tile map.
+// It only moves them and reports collisions; the world decides what a hit
+// does, the same way it resolves melee.
+package projectile
+
+import (
+	"math"
+
+	"example.com/go-quest/rpg"
+)
+
+// Owner says which side fired a shot; shots never hit their own side.
+type Owner int
+
+const (
+	Enemy Owner = iota
+	Player
+)
+
+// Projectile is one shot in flight. X,Y is its center in pixels (not a
+// sprite's top-left like players and enemies).
+type Projectile struct {
+	X, Y   float64 // center (pixels)
+	VX, VY float64 // pixels per second
+	Owner  Owner
+
+	// The hit is resolved on impact from the shooter's stats as they were
+	// when it fired, scaled by Damage (0 = 1).
+	Attacker rpg.Stats
+	Kind     rpg.DamageKind
+	Damage   float64
+	Effects  []rpg.Effect // inflicted on whatever it hits
+
+	// OnHit, if set, is told the damage the shot dealt (vampiric shooters heal).
+	OnHit func(damage float64)
+
+	Life   float64 // seconds left before it fizzles out
+	Radius float64 // hit radius (pixels)
+	Icon   string  // atlas key to draw ("" = a plain dot)
+
+	Dead bool
+}
+
+// Aim points p from (x,y) at (tx,ty) with the given speed (px/s).
+func (p *Projectile) Aim(x, y, tx, ty, speed float64) {
+	p.X, p.Y = x, y
+	dx, dy := tx-x, ty-y
+	d := math.Hypot(dx, dy)
+	if d < 1e-6 {
+		p.VX, p.VY = 0, 0
+		return
+	}
+	p.VX, p.VY = dx/d*speed, dy/d*speed
+}
+
+// Update moves p by dt seconds. It dies when its lifetime runs out or its
+// center enters a tile that isn't passable; fast shots move in sub-steps
+// of at most half a tile so they can't skip through a wall.
+func (p *Projectile) Update(dt float64, tileSize int, passable func(tx, ty int) bool) {
+	if p.Dead {
+		return
+	}
+	p.Life -= dt
+	if p.Life <= 0 {
+		p.Dead = true
+		return
+	}
+	ts := float64(tileSize)
+	steps := max(int(math.Ceil(math.Hypot(p.VX, p.VY)*dt/(ts/2))), 1)
+	sdt := dt / float64(steps)
+	for i := 0; i < steps; i++ {
+		p.X += p.VX * sdt
+		p.Y += p.VY * sdt
+		if !passable(int(math.Floor(p.X/ts)), int(math.Floor(p.Y/ts))) {
+			p.Dead = true
+			return
+		}
+	}
+}
+
+// Hits reports whether p touches a round body of radius r centered at (x,y).
+func (p *Projectile) Hits(x, y, r float64) bool {
+	return !p.Dead && math.Hypot(p.X-x, p.Y-y) <= p.Radius+r
+}
+
+// Sweep drops dead projectiles from list, reusing its storage.
+func Sweep(list []Projectile) []Projectile {
+	out := list[:0]
+	for _, p := range list {
+		if !p.Dead {
+			out = append(out, p)
+		}
+	}
+	return out
+}
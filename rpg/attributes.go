@@ -10,5 +10,95 @@ type Attributes struct {
 	Wis   int // Wisdom
 	Lck   int // Luck
 
+	XP      int // experience toward the next level
 	Unspent int // optional: points to distribute
 }
+
+// AttrID names one of the six spendable attributes.
+type AttrID int
+
+const (
+	AttrNone AttrID = iota
+	AttrStr
+	AttrDex
+	AttrInt
+	AttrVit
+	AttrWis
+	AttrLck
+)
+
+// AttrIDs lists the spendable attributes in display order.
+var AttrIDs = []AttrID{AttrStr, AttrDex, AttrInt, AttrVit, AttrWis, AttrLck}
+
+func (id AttrID) String() string {
+	switch id {
+	case AttrStr:
+		return "STR"
+	case AttrDex:
+		return "DEX"
+	case AttrInt:
+		return "INT"
+	case AttrVit:
+		return "VIT"
+	case AttrWis:
+		return "WIS"
+	case AttrLck:
+		return "LCK"
+	}
+	return "-"
+}
+
+// Get returns the value of attribute id (0 for AttrNone).
+func (a Attributes) Get(id AttrID) int {
+	if p := a.field(id); p != nil {
+		return *p
+	}
+	return 0
+}
+
+func (a *Attributes) field(id AttrID) *int {
+	switch id {
+	case AttrStr:
+		return &a.Str
+	case AttrDex:
+		return &a.Dex
+	case AttrInt:
+		return &a.Int
+	case AttrVit:
+		return &a.Vit
+	case AttrWis:
+		return &a.Wis
+	case AttrLck:
+		return &a.Lck
+	}
+	return nil
+}
+
+// Spend moves one Unspent point into attribute id. It returns false if
+// there are no points left or id isn't a spendable attribute.
+func (a *Attributes) Spend(id AttrID) bool {
+	p := a.field(id)
+	if p == nil || a.Unspent <= 0 {
+		return false
+	}
+	*p++
+	a.Unspent--
+	return true
+}
+
+// GainXP adds experience and applies every level-up it pays for, each
+// granting PointsPerLevel unspent points. It returns the levels gained.
+func (a *Attributes) GainXP(n int) int {
+	if n <= 0 {
+		return 0
+	}
+	a.XP += n
+	gained := 0
+	for a.XP >= XPToNext(a.Level) {
+		a.XP -= XPToNext(a.Level)
+		a.Level++
+		a.Unspent += PointsPerLevel
+		gained++
+	}
+	return gained
+}
@@ -0,0 +1,75 @@
+package rpg
+
+import (
+	"fmt"
+	"math/rand/v2"
+)
+
+// DamageKind picks which offense stat powers a hit and which defense stat
+// mitigates it.
+type DamageKind int
+
+const (
+	Physical DamageKind = iota // Attack vs Defense
+	Magical                    // Magic vs Resist
+)
+
+func (k DamageKind) String() string {
+	if k == Magical {
+		return "magic"
+	}
+	return "physical"
+}
+
+// Tuning for ResolveHit.
+const (
+	// Variance spreads raw damage by ±15%.
+	Variance = 0.15
+	// ArmorK is the mitigation curve: a defense of ArmorK halves damage,
+	// and no amount of defense ever blocks a hit completely.
+	ArmorK = 20.0
+	// MinDamage is the least a landed hit can do.
+	MinDamage = 1.0
+)
+
+// HitResult describes one resolved hit, for the game to apply and for the
+// UI / logs to show.
+type HitResult struct {
+	Kind      DamageKind
+	Raw       float64 // after variance and crit, before mitigation
+	Mitigated float64 // how much Defense/Resist soaked up
+	Damage    float64 // what the defender actually loses
+	Crit      bool
+}
+
+func (h HitResult) String() string {
+	s := fmt.Sprintf("%.0f %s", h.Damage, h.Kind)
+	if h.Crit {
+		s += " (crit)"
+	}
+	return s
+}
+
+// ResolveHit rolls one hit from attacker on defender:
+//
+//	raw    = power × (1 ± Variance), × CritMult on a crit
+//	damage = raw × ArmorK / (ArmorK + defense), at least MinDamage
+//
+// where power/defense are Attack/Defense for Physical hits and
+// Magic/Resist for Magical ones. Only the attacker's offense and crit
+// fields and the defender's defense fields are read.
+func ResolveHit(attacker, defender Stats, kind DamageKind, rng *rand.Rand) HitResult {
+	power, def := attacker.Attack, defender.Defense
+	if kind == Magical {
+		power, def = attacker.Magic, defender.Resist
+	}
+	h := HitResult{Kind: kind}
+	h.Raw = float64(max(power, 0)) * (1 + Variance*(2*rng.Float64()-1))
+	if rng.Float64() < attacker.CritChance {
+		h.Crit = true
+		h.Raw *= max(attacker.CritMult, 1)
+	}
+	h.Damage = max(h.Raw*ArmorK/(ArmorK+float64(max(def, 0))), MinDamage)
+	h.Mitigated = max(h.Raw-h.Damage, 0)
+	return h
+}
@@ -0,0 +1,116 @@
+package rpg
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+)
+
+const rolls = 2000
+
+func testRand() *rand.Rand { return rand.New(rand.NewPCG(1, 2)) }
+
+func TestResolveHitVariance(t *testing.T) {
+	r := testRand()
+	atk := Stats{Attack: 100}
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for i := 0; i < rolls; i++ {
+		h := ResolveHit(atk, Stats{}, Physical, r)
+		if h.Crit {
+			t.Fatalf("crit with CritChance 0")
+		}
+		if h.Damage < 100*(1-Variance) || h.Damage > 100*(1+Variance) {
+			t.Fatalf("damage %v outside 100 ±%v%%", h.Damage, Variance*100)
+		}
+		lo, hi = min(lo, h.Damage), max(hi, h.Damage)
+	}
+	// the whole band gets used, not just its middle
+	if lo > 90 || hi < 110 {
+		t.Fatalf("damage only ranged %v..%v over %d rolls", lo, hi, rolls)
+	}
+}
+
+func TestResolveHitArmor(t *testing.T) {
+	r := testRand()
+	tests := []struct {
+		def  int
+		want float64 // fraction of raw damage that gets through
+	}{
+		{0, 1},
+		{int(ArmorK), 0.5},
+		{3 * int(ArmorK), 0.25},
+		{-10, 1}, // negative defense doesn't amplify
+	}
+	for _, tt := range tests {
+		h := ResolveHit(Stats{Attack: 100}, Stats{Defense: tt.def}, Physical, r)
+		if got := h.Damage / h.Raw; math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("defense %d: %v of raw damage got through, want %v", tt.def, got, tt.want)
+		}
+		if math.Abs(h.Raw-h.Damage-h.Mitigated) > 1e-9 {
+			t.Errorf("defense %d: raw %v - damage %v != mitigated %v", tt.def, h.Raw, h.Damage, h.Mitigated)
+		}
+	}
+}
+
+func TestResolveHitMagical(t *testing.T) {
+	r := testRand()
+	atk := Stats{Attack: 1000, Magic: 100}
+	def := Stats{Defense: 1000, Resist: int(ArmorK)}
+	h := ResolveHit(atk, def, Magical, r)
+	if h.Raw < 100*(1-Variance) || h.Raw > 100*(1+Variance) {
+		t.Fatalf("magical raw %v isn't driven by Magic", h.Raw)
+	}
+	if got := h.Damage / h.Raw; math.Abs(got-0.5) > 1e-9 {
+		t.Fatalf("magical hit: %v got through, want Resist to halve it", got)
+	}
+}
+
+func TestResolveHitMinDamage(t *testing.T) {
+	r := testRand()
+	for _, atk := range []int{0, 1, -5} {
+		for i := 0; i < 100; i++ {
+			h := ResolveHit(Stats{Attack: atk}, Stats{Defense: 10000}, Physical, r)
+			if h.Damage != MinDamage {
+				t.Fatalf("attack %d vs huge defense: damage %v, want %v", atk, h.Damage, MinDamage)
+			}
+		}
+	}
+}
+
+func TestResolveHitCrit(t *testing.T) {
+	tests := []struct {
+		name   string
+		chance float64 // 0 or 1, so every roll is the same kind
+		mult   float64
+		lo, hi float64 // bounds on Raw for Attack 100
+	}{
+		{"always crit x2", 1, 2, 200 * (1 - Variance), 200 * (1 + Variance)},
+		{"crit mult below 1 counts as 1", 1, 0.5, 100 * (1 - Variance), 100 * (1 + Variance)},
+		{"never crit", 0, 3, 100 * (1 - Variance), 100 * (1 + Variance)},
+	}
+	for _, tt := range tests {
+		r := testRand()
+		atk := Stats{Attack: 100, CritChance: tt.chance, CritMult: tt.mult}
+		for i := 0; i < rolls; i++ {
+			h := ResolveHit(atk, Stats{}, Physical, r)
+			if h.Raw < tt.lo || h.Raw > tt.hi {
+				t.Fatalf("%s: raw %v outside %v..%v", tt.name, h.Raw, tt.lo, tt.hi)
+			}
+			if h.Crit != (tt.chance == 1) {
+				t.Fatalf("%s: crit = %v", tt.name, h.Crit)
+			}
+		}
+	}
+
+	// a 25% chance crits about a quarter of the time
+	r := testRand()
+	crits := 0
+	for i := 0; i < rolls; i++ {
+		if ResolveHit(Stats{Attack: 100, CritChance: 0.25, CritMult: 2}, Stats{}, Physical, r).Crit {
+			crits++
+		}
+	}
+	if f := float64(crits) / rolls; f < 0.2 || f > 0.3 {
+		t.Fatalf("25%% crit chance critted %.1f%% of the time", f*100)
+	}
+}
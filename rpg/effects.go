@@ -0,0 +1,176 @@
+package rpg
+
+/* ---------- Timed status effects (buffs, debuffs, DoTs) ---------- */
+
+// EffectID identifies an effect for stacking: applying an effect whose ID
+// is already active follows the new effect's StackRule.
+type EffectID string
+
+// StackRule says what re-applying an active effect does.
+type StackRule int
+
+const (
+	Refresh StackRule = iota // reset the timer, keep one stack
+	Stack                    // add a stack (up to MaxStacks) and reset the timer
+	Ignore                   // keep the running one untouched
+)
+
+// Effect describes a status effect. Mods apply once per stack while the
+// effect is active; OnTick runs every Interval seconds with the stack count.
+type Effect struct {
+	ID        EffectID
+	Name      string
+	Duration  float64 // seconds; <= 0 lasts until removed
+	Stack     StackRule
+	MaxStacks int // cap for Stack; 0 = no cap
+
+	Mods []Modifier
+
+	Interval float64 // seconds between ticks; 0 = 1s
+	OnTick   func(s *Stats, stacks int)
+}
+
+// ActiveEffect is an Effect currently running on someone.
+type ActiveEffect struct {
+	Effect
+	Remaining float64 // seconds left (ignored when Duration <= 0)
+	Stacks    int
+	tick      float64 // time until the next OnTick
+}
+
+// Effects is the set of effects on one character. The zero value is ready.
+type Effects struct {
+	list []*ActiveEffect
+}
+
+// Apply adds e or re-applies it per its StackRule. It returns true if the
+// modifiers changed, meaning the owner should recompute its stats.
+func (fx *Effects) Apply(e Effect) bool {
+	if e.Interval <= 0 {
+		e.Interval = 1
+	}
+	if a := fx.find(e.ID); a != nil {
+		switch e.Stack {
+		case Ignore:
+			return false
+		case Stack:
+			a.Effect, a.Remaining = e, e.Duration
+			if e.MaxStacks <= 0 || a.Stacks < e.MaxStacks {
+				a.Stacks++
+				return len(e.Mods) > 0
+			}
+			return false
+		default: // Refresh (the new copy's mods replace the old ones)
+			a.Effect, a.Remaining = e, e.Duration
+			return len(e.Mods) > 0
+		}
+	}
+	fx.list = append(fx.list, &ActiveEffect{Effect: e, Remaining: e.Duration, Stacks: 1, tick: e.Interval})
+	return len(e.Mods) > 0
+}
+
+// Update advances timers by dt, runs due ticks against s and drops expired
+// effects. It returns true if an effect with modifiers ended, meaning the
+// owner should recompute its stats.
+func (fx *Effects) Update(dt float64, s *Stats) bool {
+	changed := false
+	kept := fx.list[:0]
+	for _, a := range fx.list {
+		if a.OnTick != nil {
+			a.tick -= dt
+			for a.tick <= 0 {
+				a.OnTick(s, a.Stacks)
+				a.tick += a.Interval
+			}
+		}
+		if a.Duration > 0 {
+			a.Remaining -= dt
+			if a.Remaining <= 0 {
+				changed = changed || len(a.Mods) > 0
+				continue
+			}
+		}
+		kept = append(kept, a)
+	}
+	clear(fx.list[len(kept):])
+	fx.list = kept
+	return changed
+}
+
+// Remove ends effect id early. It returns true if modifiers changed.
+func (fx *Effects) Remove(id EffectID) bool {
+	for i, a := range fx.list {
+		if a.ID == id {
+			fx.list = append(fx.list[:i], fx.list[i+1:]...)
+			return len(a.Mods) > 0
+		}
+	}
+	return false
+}
+
+// Has reports whether effect id is active.
+func (fx *Effects) Has(id EffectID) bool { return fx.find(id) != nil }
+
+// Active returns the running effects (for UI); don't modify them.
+func (fx *Effects) Active() []*ActiveEffect { return fx.list }
+
+// Mods returns the modifiers of every active effect, repeated per stack,
+// ready to be passed to Recompute after the permanent ones.
+func (fx *Effects) Mods() []Modifier {
+	var out []Modifier
+	for _, a := range fx.list {
+		for i := 0; i < a.Stacks; i++ {
+			out = append(out, a.Mods...)
+		}
+	}
+	return out
+}
+
+func (fx *Effects) find(id EffectID) *ActiveEffect {
+	for _, a := range fx.list {
+		if a.ID == id {
+			return a
+		}
+	}
+	return nil
+}
+
+/* ---------- Common effects ---------- */
+
+// Poison deals dps damage per second per stack; up to 5 stacks.
+func Poison(dps, secs float64) Effect {
+	return Effect{
+		ID: "poison", Name: "Poisoned", Duration: secs, Stack: Stack, MaxStacks: 5,
+		OnTick: func(s *Stats, n int) { hurt(s, dps*float64(n)) },
+	}
+}
+
+// Burn deals dps damage per second and lowers Defense by 20%; re-applying
+// refreshes it.
+func Burn(dps, secs float64) Effect {
+	return Effect{
+		ID: "burn", Name: "Burning", Duration: secs, Stack: Refresh,
+		Mods:   []Modifier{Mult{DefenseMul: 0.8}},
+		OnTick: func(s *Stats, n int) { hurt(s, dps) },
+	}
+}
+
+// Regen heals hps HP per second; re-applying refreshes it.
+func Regen(hps, secs float64) Effect {
+	return Effect{
+		ID: "regen", Name: "Regenerating", Duration: secs, Stack: Refresh,
+		OnTick: func(s *Stats, n int) { s.HP = min(s.HP+hps, float64(s.HPMax)) },
+	}
+}
+
+// Haste multiplies move speed by mul; re-applying refreshes it.
+func Haste(mul, secs float64) Effect {
+	return Effect{
+		ID: "haste", Name: "Haste", Duration: secs, Stack: Refresh,
+		Mods: []Modifier{Mult{SpeedMul: mul}},
+	}
+}
+
+func hurt(s *Stats, d float64) {
+	s.HP = max(s.HP-d, 0)
+}
@@ -0,0 +1,13 @@
+package rpg
+
+// PointsPerLevel is how many attribute points each level-up grants.
+const PointsPerLevel = 3
+
+// XPToNext is the experience needed to go from level to level+1:
+// 100, 300, 600, 1000, ... (50·L·(L+1)).
+func XPToNext(level int) int {
+	if level < 1 {
+		level = 1
+	}
+	return 50 * level * (level + 1)
+}
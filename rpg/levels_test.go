@@ -0,0 +1,81 @@
+package rpg
+
+import "testing"
+
+func TestXPToNext(t *testing.T) {
+	tests := []struct{ level, want int }{
+		{1, 100},
+		{2, 300},
+		{3, 600},
+		{4, 1000},
+		{10, 5500},
+		{0, 100}, // below level 1 counts as level 1
+	}
+	for _, tt := range tests {
+		if got := XPToNext(tt.level); got != tt.want {
+			t.Errorf("XPToNext(%d) = %d, want %d", tt.level, got, tt.want)
+		}
+	}
+}
+
+func TestGainXP(t *testing.T) {
+	tests := []struct {
+		name      string
+		level, xp int // starting point
+		gain      int
+		gained    int // levels
+		wantLevel int
+		wantXP    int // left toward the next level
+	}{
+		{"short of a level", 1, 0, 99, 0, 1, 99},
+		{"exactly one level", 1, 0, 100, 1, 2, 0},
+		{"carry over", 1, 50, 100, 1, 2, 50},
+		{"two levels at once", 1, 0, 100 + 300, 2, 3, 0},
+		{"three levels and change", 1, 0, 100 + 300 + 600 + 7, 3, 4, 7},
+		{"nothing", 3, 20, 0, 0, 3, 20},
+		{"negative", 3, 20, -50, 0, 3, 20},
+	}
+	for _, tt := range tests {
+		a := Attributes{Level: tt.level, XP: tt.xp}
+		got := a.GainXP(tt.gain)
+		if got != tt.gained || a.Level != tt.wantLevel || a.XP != tt.wantXP {
+			t.Errorf("%s: gained %d to level %d with %d XP, want %d to level %d with %d XP",
+				tt.name, got, a.Level, a.XP, tt.gained, tt.wantLevel, tt.wantXP)
+		}
+		if want := tt.gained * PointsPerLevel; a.Unspent != want {
+			t.Errorf("%s: %d unspent points, want %d", tt.name, a.Unspent, want)
+		}
+	}
+}
+
+func TestSpend(t *testing.T) {
+	a := Attributes{Level: 1, Str: 4, Dex: 6}
+	a.GainXP(100)
+	if a.Unspent != PointsPerLevel {
+		t.Fatalf("%d points after a level-up, want %d", a.Unspent, PointsPerLevel)
+	}
+
+	for i := 0; i < PointsPerLevel; i++ {
+		if !a.Spend(AttrStr) {
+			t.Fatalf("spend %d of %d failed", i+1, PointsPerLevel)
+		}
+	}
+	if a.Str != 4+PointsPerLevel || a.Unspent != 0 {
+		t.Fatalf("after spending: Str %d, %d unspent; want %d, 0", a.Str, a.Unspent, 4+PointsPerLevel)
+	}
+	if a.Spend(AttrDex) || a.Dex != 6 {
+		t.Fatalf("spent a point that wasn't there")
+	}
+
+	a.Unspent = 1
+	if a.Spend(AttrNone) || a.Unspent != 1 {
+		t.Fatalf("spent a point on AttrNone")
+	}
+	for _, id := range AttrIDs {
+		a.Unspent = 1
+		before := a.Get(id)
+		if !a.Spend(id) || a.Get(id) != before+1 {
+			t.Fatalf("spending on %v didn't raise it", id)
+		}
+	}
+}
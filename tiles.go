@@ -1,19 +1,14 @@
 package main
 
-import "image"
+import (
+	"image"
 
-// Tile IDs — kept small and centralised
-const (
-	TEmpty = iota
-	TFloor
-	TWall
-	TDoor
-	TWater
+	"example.com/go-quest/world"
 )
 
 // Tile & viewport sizing
 const (
-	TileSize   = 32
+	TileSize   = world.TileSize
 	ViewTilesW = 20
 	ViewTilesH = 15
 
@@ -22,12 +17,6 @@ const (
 	ViewH = ViewTilesH * TileSize
 )
 
-// Helpers for tile indexing and bounds
-func (g *Game) idx(x, y int) int       { return y*g.W + x }
-func (g *Game) inBounds(x, y int) bool { return x >= 0 && y >= 0 && x < g.W && y < g.H }
-func (g *Game) at(x, y int) int        { return g.Tiles[g.idx(x, y)] }
-func (g *Game) set(x, y, v int)        { g.Tiles[g.idx(x, y)] = v }
-
 // small utility helpers used by dungeon/camera/etc.
 func min(a, b int) int {
 	if a < b {
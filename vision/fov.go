@@ -0,0 +1,107 @@
+package vision
+
+// FOV computes the field of view from tile (ox,oy) out to radius tiles
+// using symmetric shadowcasting, and calls mark for every visible tile
+// (a tile may be marked more than once). Walls bounding the view are
+// marked so they can be drawn; floor tiles are only marked when the
+// origin would also be visible from them, so "I can see it" always means
+// "it can see me" — the same guarantee LineOfSight gives enemies.
+//
+// Slopes are kept as exact fractions, so there are no float artifacts
+// along diagonals.
+func FOV(ox, oy, radius int, opaque Opaque, mark func(tx, ty int)) {
+	mark(ox, oy)
+	if radius <= 0 {
+		return
+	}
+	for q := 0; q < 4; q++ {
+		s := &shadowcast{q: q, ox: ox, oy: oy, radius: radius, opaque: opaque, mark: mark}
+		s.scan(row{depth: 1, start: slope{-1, 1}, end: slope{1, 1}})
+	}
+}
+
+// slope is num/den with den > 0.
+type slope struct{ num, den int }
+
+// row is one line of tiles at a fixed distance from the origin, between
+// two slopes, in quadrant-local coordinates.
+type row struct {
+	depth      int
+	start, end slope
+}
+
+// shadowcast scans one quadrant. Quadrant-local (depth,col) maps to map
+// coordinates through transform.
+type shadowcast struct {
+	q, ox, oy int
+	radius    int
+	opaque    Opaque
+	mark      func(tx, ty int)
+}
+
+func (s *shadowcast) transform(depth, col int) (int, int) {
+	switch s.q {
+	case 0: // north
+		return s.ox + col, s.oy - depth
+	case 1: // east
+		return s.ox + depth, s.oy + col
+	case 2: // south
+		return s.ox + col, s.oy + depth
+	default: // west
+		return s.ox - depth, s.oy + col
+	}
+}
+
+func (s *shadowcast) scan(r row) {
+	if r.depth > s.radius {
+		return
+	}
+	// Columns covered by the row: depth*start rounded half up to
+	// depth*end rounded half down.
+	minCol := floorDiv(2*r.depth*r.start.num+r.start.den, 2*r.start.den)
+	maxCol := ceilDiv(2*r.depth*r.end.num-r.end.den, 2*r.end.den)
+	rr := s.radius*s.radius + s.radius // a rounder circle than r*r
+
+	const none, floor, wall = 0, 1, 2
+	prev := none
+	for col := minCol; col <= maxCol; col++ {
+		tx, ty := s.transform(r.depth, col)
+		cur := floor
+		if s.opaque(tx, ty) {
+			cur = wall
+		}
+		inRange := r.depth*r.depth+col*col <= rr
+		if inRange && (cur == wall || symmetric(r, col)) {
+			s.mark(tx, ty)
+		}
+		if prev == wall && cur == floor {
+			r.start = slope{2*col - 1, 2 * r.depth}
+		}
+		if prev == floor && cur == wall {
+			next := row{depth: r.depth + 1, start: r.start, end: slope{2*col - 1, 2 * r.depth}}
+			s.scan(next)
+		}
+		prev = cur
+	}
+	if prev == floor {
+		s.scan(row{depth: r.depth + 1, start: r.start, end: r.end})
+	}
+}
+
+// symmetric reports whether col lies inside the row's slopes proper
+// (not just touched by the rounded range).
+func symmetric(r row, col int) bool {
+	return col*r.start.den >= r.depth*r.start.num && col*r.end.den <= r.depth*r.end.num
+}
+
+func floorDiv(a, b int) int {
+	q := a / b
+	if (a%b != 0) && ((a < 0) != (b < 0)) {
+		q--
+	}
+	return q
+}
+
+func ceilDiv(a, b int) int {
+	return -floorDiv(-a, b)
+}
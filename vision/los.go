@@ -0,0 +1,50 @@
+package vision
+
+// Opaque reports whether tile (tx,ty) blocks sight.
+type Opaque = func(tx, ty int) bool
+
+// LineOfSight walks a Bresenham line over the tile grid from (x0,y0) to
+// (x1,y1) and reports whether no tile strictly between them is opaque.
+// The end tiles themselves never block (you can see a wall, or a player
+// standing in a doorway). It's symmetric: swapping the ends gives the same
+// answer, because the walk always runs from the lower endpoint.
+func LineOfSight(x0, y0, x1, y1 int, opaque Opaque) bool {
+	if y1 < y0 || (y1 == y0 && x1 < x0) {
+		x0, y0, x1, y1 = x1, y1, x0, y0
+	}
+	dx := abs(x1 - x0)
+	dy := -abs(y1 - y0)
+	sx, sy := 1, 1
+	if x0 > x1 {
+		sx = -1
+	}
+	if y0 > y1 {
+		sy = -1
+	}
+	err := dx + dy
+	x, y := x0, y0
+	for {
+		if x == x1 && y == y1 {
+			return true
+		}
+		if (x != x0 || y != y0) && opaque(x, y) {
+			return false
+		}
+		e2 := 2 * err
+		if e2 >= dy {
+			err += dy
+			x += sx
+		}
+		if e2 <= dx {
+			err += dx
+			y += sy
+		}
+	}
+}
+
+func abs(a int) int {
+	if a < 0 {
+		return -a
+	}
+	return a
+}
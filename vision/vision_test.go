@@ -0,0 +1,154 @@
+package vision
+
+import (
+	"math/rand/v2"
+	"testing"
+)
+
+// testMap is a w x h grid of walls; everything outside it is a wall.
+type testMap struct {
+	w, h  int
+	walls []bool
+}
+
+func (m *testMap) opaque(tx, ty int) bool {
+	if tx < 0 || ty < 0 || tx >= m.w || ty >= m.h {
+		return true
+	}
+	return m.walls[ty*m.w+tx]
+}
+
+// randomMap scatters walls over about a quarter of the tiles.
+func randomMap(seed uint64, w, h int) *testMap {
+	r := rand.New(rand.NewPCG(seed, seed))
+	m := &testMap{w: w, h: h, walls: make([]bool, w*h)}
+	for i := range m.walls {
+		m.walls[i] = r.IntN(4) == 0
+	}
+	return m
+}
+
+// parseMap reads rows of text: '#' is a wall, anything else is floor.
+func parseMap(rows ...string) *testMap {
+	m := &testMap{w: len(rows[0]), h: len(rows), walls: make([]bool, len(rows[0])*len(rows))}
+	for y, row := range rows {
+		for x, c := range row {
+			m.walls[y*m.w+x] = c == '#'
+		}
+	}
+	return m
+}
+
+// seen returns the set of tiles visible from (ox,oy).
+func (m *testMap) seen(ox, oy, radius int) []bool {
+	out := make([]bool, m.w*m.h)
+	FOV(ox, oy, radius, m.opaque, func(tx, ty int) {
+		if tx >= 0 && ty >= 0 && tx < m.w && ty < m.h {
+			out[ty*m.w+tx] = true
+		}
+	})
+	return out
+}
+
+func TestFOVSymmetric(t *testing.T) {
+	const radius = 8
+	for seed := uint64(1); seed <= 5; seed++ {
+		m := randomMap(seed, 24, 24)
+		views := make([][]bool, m.w*m.h)
+		for i := range views {
+			if !m.walls[i] {
+				views[i] = m.seen(i%m.w, i/m.w, radius)
+			}
+		}
+		for a := range views {
+			for b := range views {
+				if views[a] == nil || views[b] == nil {
+					continue // walls don't look back
+				}
+				if views[a][b] != views[b][a] {
+					t.Fatalf("seed %d: (%d,%d) sees (%d,%d) = %v, but the reverse is %v",
+						seed, a%m.w, a/m.w, b%m.w, b/m.w, views[a][b], views[b][a])
+				}
+			}
+		}
+	}
+}
+
+func TestFOVWallsOcclude(t *testing.T) {
+	m := parseMap(
+		"...........",
+		"...........",
+		"...........",
+		".....#.....",
+		"...........",
+		"...........",
+	)
+	view := m.seen(5, 5, 8)
+	at := func(x, y int) bool { return view[y*m.w+x] }
+
+	if !at(5, 3) {
+		t.Errorf("the wall itself should be visible")
+	}
+	for y := 0; y <= 2; y++ {
+		if at(5, y) {
+			t.Errorf("(5,%d) is behind the wall but visible", y)
+		}
+	}
+	if !at(0, 0) || !at(10, 0) {
+		t.Errorf("corners of the open room should be visible")
+	}
+}
+
+func TestFOVRadius(t *testing.T) {
+	m := parseMap("...................")
+	view := m.seen(0, 0, 5)
+	for x := 0; x < m.w; x++ {
+		if want := x <= 5; view[x] != want {
+			t.Errorf("tile %d visible = %v, want %v", x, view[x], want)
+		}
+	}
+}
+
+func TestLineOfSightSymmetric(t *testing.T) {
+	for seed := uint64(1); seed <= 5; seed++ {
+		m := randomMap(seed, 20, 20)
+		for x0 := 0; x0 < m.w; x0++ {
+			for y0 := 0; y0 < m.h; y0++ {
+				for x1 := 0; x1 < m.w; x1++ {
+					for y1 := 0; y1 < m.h; y1++ {
+						ab := LineOfSight(x0, y0, x1, y1, m.opaque)
+						ba := LineOfSight(x1, y1, x0, y0, m.opaque)
+						if ab != ba {
+							t.Fatalf("seed %d: LOS (%d,%d)->(%d,%d) = %v, reverse = %v", seed, x0, y0, x1, y1, ab, ba)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestLineOfSight(t *testing.T) {
+	m := parseMap(
+		".....",
+		"..#..",
+		".....",
+	)
+	tests := []struct {
+		name           string
+		x0, y0, x1, y1 int
+		want           bool
+	}{
+		{"open row", 0, 0, 4, 0, true},
+		{"through the wall", 0, 1, 4, 1, false},
+		{"onto the wall", 0, 1, 2, 1, true},
+		{"from the wall", 2, 1, 4, 1, true},
+		{"around the wall", 0, 2, 4, 2, true},
+		{"same tile", 3, 2, 3, 2, true},
+	}
+	for _, tt := range tests {
+		if got := LineOfSight(tt.x0, tt.y0, tt.x1, tt.y1, m.opaque); got != tt.want {
+			t.Errorf("%s: LineOfSight(%d,%d -> %d,%d) = %v, want %v", tt.name, tt.x0, tt.y0, tt.x1, tt.y1, got, tt.want)
+		}
+	}
+}
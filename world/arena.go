@@ -0,0 +1,128 @@
+package world
+
+import (
+	"image"
+
+	"example.com/go-quest/dungeon"
+	"example.com/go-quest/enemies"
+	"example.com/go-quest/move"
+)
+
+// bossEvery puts a boss arena on every bossEvery-th floor (4, 8, 12...).
+const bossEvery = 4
+
+func hasBoss(depth int) bool { return depth%bossEvery == bossEvery-1 }
+
+// spawnBoss puts a random registered boss in the middle of the floor's
+// arena, scaled to the depth like every other enemy. Down stairs inside
+// the arena stay hidden until the boss is dead (see updateArena), so the
+// fight can't be skipped.
+func (w *World) spawnBoss() {
+	a := w.Map.Arena
+	ids := enemies.BossIDs()
+	if a == nil || len(ids) == 0 {
+		return
+	}
+	e := enemies.New(ids[w.rng.IntN(len(ids))])
+	if e == nil {
+		return
+	}
+	e.ScaleLevel(w.Depth)
+	x, y := w.Map.RoomCenter(a.Room)
+	e.SetPos(float64(x*TileSize), float64(y*TileSize))
+	w.Enemies = append(w.Enemies, e)
+	if w.DownX >= 0 && image.Pt(w.DownX, w.DownY).In(w.Map.Rooms[a.Room]) {
+		w.Set(w.DownX, w.DownY, TFloor)
+	}
+}
+
+// Boss returns the floor's living boss, or nil.
+func (l *Level) Boss() enemies.Boss {
+	for _, e := range l.Enemies {
+		if b, ok := e.(enemies.Boss); ok && e.IsAlive() {
+			return b
+		}
+	}
+	return nil
+}
+
+// BossFight returns the boss the player is locked in with, or nil when
+// no arena fight is on (for the UI's boss health bar).
+func (w *World) BossFight() enemies.Boss {
+	if a := w.Map.Arena; a == nil || !a.Sealed() {
+		return nil
+	}
+	return w.Boss()
+}
+
+// updateArena seals the boss arena behind the player when they walk in
+// while its boss lives, and reopens it (as doors) once the boss is dead,
+// revealing the down stairs the boss was guarding.
+func (w *World) updateArena() {
+	a := w.Map.Arena
+	if a == nil {
+		return
+	}
+	boss := w.Boss()
+	switch {
+	case !a.Sealed() && boss != nil:
+		if !w.inRoom(a.Room) {
+			return
+		}
+		a.Gates = w.seal(a.Room)
+		w.say("The doors slam shut!", 2.0)
+	case a.Sealed() && boss == nil:
+		w.unseal(a.Gates)
+		a.Gates = nil
+		w.say("The way is open.", 2.0)
+	}
+	if boss == nil && w.DownX >= 0 && w.At(w.DownX, w.DownY) != TStairsDown {
+		w.Set(w.DownX, w.DownY, TStairsDown)
+	}
+}
+
+// seal walls up every entrance of room i and returns the sealed tiles.
+func (w *World) seal(room int) []image.Point {
+	gates := w.Map.Entrances(room, walkable)
+	for _, g := range gates {
+		w.Set(g.X, g.Y, TWall)
+	}
+	return gates
+}
+
+// unseal turns sealed entrances into (open) doors.
+func (w *World) unseal(gates []image.Point) {
+	for _, g := range gates {
+		w.Set(g.X, g.Y, TDoor)
+	}
+}
+
+// inRoom reports whether the player stands inside room i's rectangle.
+func (w *World) inRoom(room int) bool {
+	ptx, pty := w.playerTile()
+	return image.Pt(ptx, pty).In(w.Map.Rooms[room])
+}
+
+// summon adds an enemy of type id at pixel (x,y), scaled to the depth.
+// Nothing appears if (x,y) isn't walkable.
+func (w *World) summon(id string, x, y float64) {
+	if tx, ty := move.Tile(x, y, TileSize); !w.Passable(tx, ty) {
+		return
+	}
+	e := enemies.New(id)
+	if e == nil {
+		return
+	}
+	e.ScaleLevel(w.Depth)
+	e.SetPos(x, y)
+	w.Enemies = append(w.Enemies, e)
+}
+
+// arenaGenerator wraps a floor generator with the boss arena hook on
+// floors that have a boss.
+func arenaGenerator(g dungeon.Generator, depth int) dungeon.Generator {
+	if hasBoss(depth) {
+		return dungeon.BossArena{Gen: g}
+	}
+	return g
+}
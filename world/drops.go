@@ -0,0 +1,114 @@
+package world
+
+import (
+	"image"
+
+	"example.com/go-quest/loot"
+)
+
+// roomKind is a flavour of room; each is stocked from its own loot table.
+type roomKind struct {
+	table  string
+	weight int
+}
+
+var roomKinds = []roomKind{
+	{table: "room.plain", weight: 80},
+	{table: "room.storeroom", weight: 15},
+	{table: "room.treasure", weight: 5},
+}
+
+// fillRooms rolls a kind for every room but the start room and spills
+// that kind's loot around a random tile in it.
+func (w *World) fillRooms() {
+	total := 0
+	for _, k := range roomKinds {
+		total += k.weight
+	}
+	for i, r := range w.Map.Rooms {
+		if i == w.Map.Start {
+			continue
+		}
+		x := w.rng.IntN(total)
+		kind := roomKinds[0]
+		for _, k := range roomKinds {
+			if x < k.weight {
+				kind = k
+				break
+			}
+			x -= k.weight
+		}
+		cx := r.Min.X + w.rng.IntN(max(r.Dx(), 1))
+		cy := r.Min.Y + w.rng.IntN(max(r.Dy(), 1))
+		w.dropLoot(kind.table, cx, cy)
+	}
+}
+
+// dropLoot rolls loot table id and spills the result around tile (tx,ty).
+func (w *World) dropLoot(id string, tx, ty int) {
+	if id == "" {
+		return
+	}
+	w.spill(loot.Generate(id, w.rng, w.Depth), tx, ty)
+}
+
+// spillRadius is how far (in tiles) drops spread from where they fell.
+const spillRadius = 3
+
+// spill lays drops out one per tile on the free floor tiles nearest to
+// (tx,ty), searching outward through walkable tiles so nothing lands
+// behind a wall. If the area fills up, the rest piles on (tx,ty).
+func (w *World) spill(drops []loot.Drop, tx, ty int) {
+	if len(drops) == 0 {
+		return
+	}
+	free := w.spillTiles(tx, ty, len(drops))
+	for i, d := range drops {
+		p := image.Pt(tx, ty)
+		if i < len(free) {
+			p = free[i]
+		}
+		if d.Item != nil {
+			w.placeItem(d.Item, p.X, p.Y)
+		} else {
+			w.ItemsOnGround = append(w.ItemsOnGround, WorldItem{ID: "gold", X: p.X, Y: p.Y, Val: d.Gold})
+		}
+	}
+}
+
+// spillTiles returns up to n floor tiles without items, nearest first
+// (breadth-first over walkable tiles within spillRadius of the origin).
+func (w *World) spillTiles(tx, ty, n int) []image.Point {
+	var out []image.Point
+	if !w.InBounds(tx, ty) {
+		return out
+	}
+	seen := map[image.Point]bool{{tx, ty}: true}
+	queue := []image.Point{{tx, ty}}
+	for len(queue) > 0 && len(out) < n {
+		p := queue[0]
+		queue = queue[1:]
+		if w.At(p.X, p.Y) == TFloor && !w.hasItemAt(p.X, p.Y) {
+			out = append(out, p)
+		}
+		for _, d := range [...]image.Point{{1, 0}, {-1, 0}, {0, 1}, {0, -1}} {
+			q := p.Add(d)
+			if seen[q] || abs(q.X-tx) > spillRadius || abs(q.Y-ty) > spillRadius || !w.Passable(q.X, q.Y) {
+				continue
+			}
+			seen[q] = true
+			queue = append(queue, q)
+		}
+	}
+	return out
+}
+
+// hasItemAt reports whether anything lies on the ground at (x,y).
+func (w *World) hasItemAt(x, y int) bool {
+	for _, it := range w.ItemsOnGround {
+		if it.X == x && it.Y == y {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,21 @@
+package world
+
+import "example.com/go-quest/rpg"
+
+// Input is one step's worth of player intent, decoupled from any device.
+// Movement is held; the action flags mean "pressed this step".
+type Input struct {
+	MoveX, MoveY float64 // -1..1 each; diagonals are normalised by the player
+
+	Attack bool
+	Pickup bool
+	Use    bool
+	Drop   bool
+
+	PrevSlot, NextSlot bool // cycle inventory selection
+	Split, Merge       bool // halve the selected stack / merge it into a matching one
+
+	Descend, Ascend bool // take the stairs under the player
+
+	Spend rpg.AttrID // put one unspent attribute point here (AttrNone = nothing)
+}
@@ -0,0 +1,166 @@
+package world
+
+import (
+	"fmt"
+	"log"
+	"math/rand/v2"
+
+	"example.com/go-quest/dungeon"
+	"example.com/go-quest/enemies"
+	"example.com/go-quest/projectile"
+)
+
+// Level is one floor of the dungeon. Each floor owns its map, enemies and
+// ground items, so it stays exactly as the player left it.
+type Level struct {
+	Depth int // 0 = top floor
+
+	// Tilemap
+	W, H  int             // map width/height in tiles
+	Tiles []int           // len = W*H
+	Map   *dungeon.Layout // rooms, corridors and start/exit from the generator
+
+	// Fog of war, indexed like Tiles
+	Explored []bool // seen at least once
+	Visible  []bool // in the player's FOV right now
+
+	Enemies       []enemies.Enemy
+	ItemsOnGround []WorldItem
+	Projectiles   []projectile.Projectile // shots in flight (not saved)
+
+	// Spawners
+	Nests    []*Nest
+	Ambushes []*Ambush
+
+	// Stairs tiles (UpX is -1 on the top floor)
+	UpX, UpY     int
+	DownX, DownY int
+
+	rng *rand.Rand // this floor's RNG, derived from World.Seed and Depth
+	src *rand.PCG  // rng's source, kept so saves can store its state
+}
+
+// floorGenerators picks the map style per floor, so levels look different.
+// Floors past the end of the list cycle through it again.
+var floorGenerators = []dungeon.Generator{
+	dungeon.RoomsCorridors{},
+	dungeon.BSP{},
+	dungeon.Caves{},
+}
+
+func floorGenerator(depth int) dungeon.Generator {
+	return floorGenerators[depth%len(floorGenerators)]
+}
+
+// levelSeed derives a per-floor seed so each floor depends only on the run
+// seed and its depth, not on the order floors were visited in.
+func levelSeed(seed uint64, depth int) uint64 {
+	return seed + uint64(depth)*0x9e3779b97f4a7c15
+}
+
+// buildLevel generates floor `depth`, makes it current and puts the player
+// on its up stairs (or in the start room on the top floor).
+func (w *World) buildLevel(depth int) {
+	// Make a dungeon: the generator depends on the floor.
+	lvl := w.newLevel(depth, arenaGenerator(floorGenerator(depth), depth))
+	w.Levels = append(w.Levels, lvl)
+	w.Level = lvl
+
+	// Stairs: up in the start room, down in the exit room.
+	sx, sy := 1, 1
+	if lvl.Map.Start >= 0 {
+		sx, sy = lvl.Map.RoomCenter(lvl.Map.Start)
+	}
+	w.Player.SetPosPixels(float64(sx*TileSize), float64(sy*TileSize))
+	if depth > 0 {
+		lvl.UpX, lvl.UpY = sx, sy
+		w.Set(sx, sy, TStairsUp)
+	}
+	lvl.DownX, lvl.DownY = w.downStairsSpot(sx, sy)
+	w.Set(lvl.DownX, lvl.DownY, TStairsDown)
+
+	// Sprinkle a few example features (optional)
+	w.placeRandomDoors(8)   // sprinkle a few doors on floor tiles
+	w.paintWaterBlobs(5, 3) // 5 blobs, radius ~3 tiles each
+
+	// Water and doors can seal off pockets: bridge them back to the start.
+	if n := lvl.Map.EnsureConnected(sx, sy, walkable); n > 0 {
+		log.Printf("floor %d connectivity: carved %d bridge(s)", depth, n)
+	}
+
+	// spawn some enemies for testing — e.g., 40 random monsters, tougher deeper down
+	w.spawnEnemiesRandom(40+5*depth, nil) // nil -> choose from all registered types
+
+	// OR spawn a weighted mix:
+	// w.spawnEnemiesRandom(20, []string{"slime"})
+	// w.spawnEnemiesRandom(10, []string{"goblin"})
+
+	// Every few floors a boss waits in an arena (see hasBoss)
+	w.spawnBoss()
+
+	// Nests keep hatching; from floor 2 on, one room is a trap
+	w.placeSpawners(min(1+depth/2, 4), min(depth, 1))
+
+	// Gold: more piles and bigger piles the deeper you go.
+	w.spawnGoldRandom(10+2*depth, 5+5*depth, 20+10*depth)
+
+	// Stock each room from its kind's loot table (gear improves with depth).
+	w.fillRooms()
+}
+
+// newLevel generates the map for floor `depth` with gen. The floor is
+// bare: no stairs, enemies or items yet.
+func (w *World) newLevel(depth int, gen dungeon.Generator) *Level {
+	lvl := &Level{
+		Depth: depth,
+		W:     100, // 100x100 tiles of world (feel free to change)
+		H:     100,
+		UpX:   -1,
+		UpY:   -1,
+		DownX: -1,
+		DownY: -1,
+		src:   dungeon.NewSource(levelSeed(w.Seed, depth)),
+	}
+	lvl.rng = rand.New(lvl.src)
+	lvl.Map = gen.Generate(lvl.W, lvl.H, TFloor, TWall, lvl.rng)
+	lvl.Tiles = lvl.Map.Tiles
+	lvl.initSight()
+	return lvl
+}
+
+// downStairsSpot picks the exit room's center, or any other room tile if the
+// exit room is also where the player starts.
+func (w *World) downStairsSpot(sx, sy int) (int, int) {
+	m := w.Map
+	if m.Exit >= 0 {
+		if x, y := m.RoomCenter(m.Exit); x != sx || y != sy {
+			return x, y
+		}
+	}
+	for i := 0; i < 100; i++ {
+		if x, y := w.randomRoomTile(); w.At(x, y) == TFloor && (x != sx || y != sy) {
+			return x, y
+		}
+	}
+	return sx + 1, sy
+}
+
+// changeLevel moves the player to floor `depth`, generating it on first
+// visit. Going down lands on the up stairs; going up lands on the down stairs.
+func (w *World) changeLevel(depth int) {
+	if depth < 0 || depth == w.Depth {
+		return
+	}
+	down := depth > w.Depth
+	if depth < len(w.Levels) {
+		w.Level = w.Levels[depth]
+		x, y := w.DownX, w.DownY
+		if down {
+			x, y = w.UpX, w.UpY
+		}
+		w.Player.SetPosPixels(float64(x*TileSize), float64(y*TileSize))
+	} else {
+		w.buildLevel(depth)
+	}
+	w.say(fmt.Sprintf("Floor %d", depth+1), 1.5)
+}
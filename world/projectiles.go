@@ -0,0 +1,70 @@
+package world
+
+import (
+	"example.com/go-quest/projectile"
+	"example.com/go-quest/rpg"
+)
+
+// bodyRadius is how close (px) a shot's edge must come to the center of a
+// player or enemy sprite to hit it.
+const bodyRadius = 12.0
+
+// fire adds a projectile to the current floor.
+func (w *World) fire(p projectile.Projectile) {
+	w.Projectiles = append(w.Projectiles, p)
+}
+
+// updateProjectiles moves every shot on the floor, resolves hits against
+// the other side and drops shots that hit something, a wall or expired.
+func (w *World) updateProjectiles(dt float64) {
+	const half = TileSize / 2
+	for i := range w.Projectiles {
+		p := &w.Projectiles[i]
+		p.Update(dt, TileSize, w.Passable)
+		if p.Dead {
+			continue
+		}
+		switch p.Owner {
+		case projectile.Enemy:
+			if p.Hits(w.Player.X+half, w.Player.Y+half, bodyRadius) {
+				dmg := w.resolveShot(p, w.Player.Stats).Damage
+				w.Player.TakeDamage(dmg)
+				if p.OnHit != nil {
+					p.OnHit(dmg)
+				}
+				for _, fx := range p.Effects {
+					w.Player.AddEffect(fx)
+				}
+				p.Dead = true
+			}
+		case projectile.Player:
+			for _, e := range w.Enemies {
+				if e.IsAlive() && p.Hits(e.X()+half, e.Y()+half, bodyRadius) {
+					dmg := w.resolveShot(p, e.Stats()).Damage
+					e.TakeDamage(dmg)
+					if p.OnHit != nil {
+						p.OnHit(dmg)
+					}
+					for _, fx := range p.Effects {
+						e.AddEffect(fx)
+					}
+					p.Dead = true
+					break
+				}
+			}
+		}
+	}
+	w.Projectiles = projectile.Sweep(w.Projectiles)
+}
+
+// resolveShot rolls a projectile's hit on defender like a melee hit, then
+// scales it by the shot's Damage factor.
+func (w *World) resolveShot(p *projectile.Projectile, defender rpg.Stats) rpg.HitResult {
+	hit := rpg.ResolveHit(p.Attacker, defender, p.Kind, w.rng)
+	if p.Damage > 0 {
+		hit.Raw *= p.Damage
+		hit.Damage = max(hit.Damage*p.Damage, rpg.MinDamage)
+		hit.Mitigated = max(hit.Raw-hit.Damage, 0)
+	}
+	return hit
+}
@@ -0,0 +1,446 @@
+package world
+
+import (
+	"encoding/json"
+	"fmt"
+	"image"
+	"math/rand/v2"
+	"os"
+
+	"example.com/go-quest/dungeon"
+	"example.com/go-quest/enemies"
+	"example.com/go-quest/inventory"
+	"example.com/go-quest/items"
+	"example.com/go-quest/loot"
+	"example.com/go-quest/player"
+	"example.com/go-quest/rpg"
+)
+
+// SaveVersion is bumped whenever the save layout changes. Older saves are
+// upgraded step by step through saveMigrations; newer ones are rejected.
+const SaveVersion = 7
+
+// saveMigrations[v] upgrades a raw version-v save to version v+1.
+var saveMigrations = map[int]func(raw map[string]json.RawMessage) error{
+	// v2: levels store their explored tiles. Older saves start every
+	// floor unexplored.
+	1: keepAsIs,
+	// v3: player mods come from equipped gear. v2's mods were the old
+	// built-in boots/haste bonuses, so they're dropped; nothing is worn.
+	2: func(raw map[string]json.RawMessage) error {
+		var p map[string]json.RawMessage
+		if err := json.Unmarshal(raw["player"], &p); err != nil {
+			return err
+		}
+		delete(p, "mods")
+		b, err := json.Marshal(p)
+		raw["player"] = b
+		return err
+	},
+	// v4: inventory slots are stacks {id, qty} instead of bare ids.
+	3: func(raw map[string]json.RawMessage) error {
+		var ids []string
+		if err := json.Unmarshal(raw["inventory"], &ids); err != nil {
+			return err
+		}
+		stacks := make([]saveStack, 0, len(ids))
+		for _, id := range ids {
+			stacks = append(stacks, saveStack{ID: id, Qty: 1})
+		}
+		b, err := json.Marshal(stacks)
+		raw["inventory"] = b
+		return err
+	},
+	// v5: rolled loot stores its rarity and affixes.
+	4: keepAsIs,
+	// v6: enemies store their elite affixes and summon counts.
+	5: keepAsIs,
+	// v7: levels store nests and ambushes; arena mode stores its waves.
+	6: keepAsIs,
+}
+
+// keepAsIs upgrades a save whose new fields are all optional: an older
+// save simply doesn't have them. The version still goes up, so older
+// builds reject the newer save instead of silently dropping the fields.
+func keepAsIs(raw map[string]json.RawMessage) error { return nil }
+
+type saveFile struct {
+	Version int    `json:"version"`
+	Seed    uint64 `json:"seed"`
+	Depth   int    `json:"depth"`
+
+	Player    savePlayer  `json:"player"`
+	Inventory []saveStack `json:"inventory"` // slot order
+	Equipped  []int       `json:"equipped"`  // inventory slots being worn
+	InvSel    int         `json:"inv_sel"`
+	Levels    []saveLevel `json:"levels"`
+	Waves     *Waves      `json:"waves,omitempty"` // arena mode only
+}
+
+// savePlayer holds the player's permanent state. Mods aren't stored: they
+// are rebuilt from the equipped items. Timed status effects are not saved
+// either: they carry callbacks, and wear off in seconds anyway.
+type savePlayer struct {
+	X     float64        `json:"x"`
+	Y     float64        `json:"y"`
+	Attr  rpg.Attributes `json:"attr"`
+	Stats rpg.Stats      `json:"stats"`
+	Gold  int            `json:"gold"`
+}
+
+type saveLevel struct {
+	Depth    int             `json:"depth"`
+	Map      *dungeon.Layout `json:"map"` // includes the tiles
+	UpX      int             `json:"up_x"`
+	UpY      int             `json:"up_y"`
+	DownX    int             `json:"down_x"`
+	DownY    int             `json:"down_y"`
+	RNG      []byte          `json:"rng"`                // PCG state
+	Explored []byte          `json:"explored,omitempty"` // fog of war, one bit per tile
+	Items    []saveItem      `json:"items"`
+	Enemies  []saveEnemy     `json:"enemies"`
+	Nests    []saveNest      `json:"nests,omitempty"`
+	Ambushes []saveAmbush    `json:"ambushes,omitempty"`
+}
+
+// saveNest and saveAmbush refer to their living enemies by index into
+// the level's saved Enemies.
+type saveNest struct {
+	X     int     `json:"x"`
+	Y     int     `json:"y"`
+	Enemy string  `json:"enemy"`
+	Every float64 `json:"every"`
+	Cap   int     `json:"cap"`
+	Timer float64 `json:"timer"`
+	Brood []int   `json:"brood,omitempty"`
+}
+
+type saveAmbush struct {
+	Room   int           `json:"room"`
+	Count  int           `json:"count"`
+	Sprung bool          `json:"sprung,omitempty"`
+	Gates  []image.Point `json:"gates,omitempty"`
+	Foes   []int         `json:"foes,omitempty"`
+}
+
+type saveStack struct {
+	ID   string     `json:"id"`
+	Qty  int        `json:"qty"`
+	Loot *loot.Spec `json:"loot,omitempty"` // rolled affixes, if any
+}
+
+type saveItem struct {
+	ID   string     `json:"id"`
+	X    int        `json:"x"`
+	Y    int        `json:"y"`
+	Val  int        `json:"val,omitempty"`  // gold amount
+	Loot *loot.Spec `json:"loot,omitempty"` // rolled affixes, if any
+}
+
+type saveEnemy struct {
+	ID      string   `json:"id"`
+	X       float64  `json:"x"`
+	Y       float64  `json:"y"`
+	HP      float64  `json:"hp"`
+	Level   int      `json:"level"`
+	Elite   []string `json:"elite,omitempty"`   // elite affix names
+	Summons int      `json:"summons,omitempty"` // minions a summoner has called in
+}
+
+// Save writes the full game state to path as JSON.
+func (w *World) Save(path string) error {
+	sf := saveFile{
+		Version: SaveVersion,
+		Seed:    w.Seed,
+		Depth:   w.Depth,
+		Player: savePlayer{
+			X: w.Player.X, Y: w.Player.Y,
+			Attr:  w.Player.Attr,
+			Stats: w.Player.Stats,
+			Gold:  w.Player.Gold,
+		},
+		InvSel: w.InvSel,
+		Waves:  w.Waves,
+	}
+	for i, s := range w.Inv.Slots {
+		sf.Inventory = append(sf.Inventory, saveStack{ID: s.Item.ID(), Qty: s.Qty, Loot: lootSpec(s.Item)})
+		if eq, ok := s.Item.(items.Equipment); ok && w.Player.IsEquipped(eq) {
+			sf.Equipped = append(sf.Equipped, i)
+		}
+	}
+
+	for _, lvl := range w.Levels {
+		rng, err := lvl.src.MarshalBinary()
+		if err != nil {
+			return fmt.Errorf("save: rng: %w", err)
+		}
+		sl := saveLevel{
+			Depth: lvl.Depth, Map: lvl.Map,
+			UpX: lvl.UpX, UpY: lvl.UpY, DownX: lvl.DownX, DownY: lvl.DownY,
+			RNG: rng, Explored: packBits(lvl.Explored),
+		}
+		for _, wi := range lvl.ItemsOnGround {
+			sl.Items = append(sl.Items, saveItem{ID: wi.ID, X: wi.X, Y: wi.Y, Val: wi.Val, Loot: lootSpec(wi.Inst)})
+		}
+		saved := map[enemies.Enemy]int{} // enemy → index in sl.Enemies
+		for _, e := range lvl.Enemies {
+			if !e.IsAlive() {
+				continue
+			}
+			saved[e] = len(sl.Enemies)
+			se := saveEnemy{
+				ID: e.ID(), X: e.X(), Y: e.Y(), HP: e.Stats().HP, Level: e.Attr().Level,
+				Summons: enemies.Summons(e),
+			}
+			for _, a := range enemies.Elite(e) {
+				se.Elite = append(se.Elite, a.String())
+			}
+			sl.Enemies = append(sl.Enemies, se)
+		}
+		for _, n := range lvl.Nests {
+			sl.Nests = append(sl.Nests, saveNest{
+				X: n.X, Y: n.Y, Enemy: n.Enemy, Every: n.Every, Cap: n.Cap, Timer: n.timer,
+				Brood: savedIndices(n.brood, saved),
+			})
+		}
+		for _, a := range lvl.Ambushes {
+			sl.Ambushes = append(sl.Ambushes, saveAmbush{
+				Room: a.Room, Count: a.Count, Sprung: a.Sprung, Gates: a.Gates,
+				Foes: savedIndices(a.foes, saved),
+			})
+		}
+		sf.Levels = append(sf.Levels, sl)
+	}
+
+	data, err := json.MarshalIndent(sf, "", "  ")
+	if err != nil {
+		return fmt.Errorf("save: %w", err)
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// Load replaces the current game state with the save at path. Items and
+// enemies are rebuilt through the items/enemies registries. On error the
+// running game is left untouched.
+func (w *World) Load(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("load: %w", err)
+	}
+	data, err = migrateSave(data)
+	if err != nil {
+		return err
+	}
+	var sf saveFile
+	if err := json.Unmarshal(data, &sf); err != nil {
+		return fmt.Errorf("load: %w", err)
+	}
+	if len(sf.Levels) == 0 || sf.Depth < 0 || sf.Depth >= len(sf.Levels) {
+		return fmt.Errorf("load: bad depth %d for %d level(s)", sf.Depth, len(sf.Levels))
+	}
+
+	levels := make([]*Level, 0, len(sf.Levels))
+	for _, sl := range sf.Levels {
+		if sl.Map == nil || len(sl.Map.Tiles) != sl.Map.W*sl.Map.H {
+			return fmt.Errorf("load: level %d has a broken map", sl.Depth)
+		}
+		lvl := &Level{
+			Depth: sl.Depth,
+			W:     sl.Map.W, H: sl.Map.H,
+			Tiles: sl.Map.Tiles, Map: sl.Map,
+			UpX: sl.UpX, UpY: sl.UpY, DownX: sl.DownX, DownY: sl.DownY,
+			src: dungeon.NewSource(levelSeed(sf.Seed, sl.Depth)),
+		}
+		if err := lvl.src.UnmarshalBinary(sl.RNG); err != nil {
+			return fmt.Errorf("load: level %d rng: %w", sl.Depth, err)
+		}
+		lvl.rng = rand.New(lvl.src)
+		lvl.initSight()
+		unpackBits(sl.Explored, lvl.Explored) // absent in older saves: all unexplored
+
+		for _, si := range sl.Items {
+			wi := WorldItem{ID: si.ID, X: si.X, Y: si.Y, Val: si.Val}
+			if si.ID != "gold" {
+				if wi.Inst, err = w.restoreItem(si.ID, si.Loot); err != nil {
+					return err
+				}
+			}
+			lvl.ItemsOnGround = append(lvl.ItemsOnGround, wi)
+		}
+		for _, se := range sl.Enemies {
+			e := enemies.New(se.ID)
+			if e == nil {
+				return fmt.Errorf("load: unknown enemy %q", se.ID)
+			}
+			e.ScaleLevel(se.Level - e.Attr().Level)
+			var affixes []enemies.EliteAffix
+			for _, name := range se.Elite {
+				a, ok := enemies.ParseEliteAffix(name)
+				if !ok {
+					return fmt.Errorf("load: unknown elite affix %q", name)
+				}
+				affixes = append(affixes, a)
+			}
+			enemies.MakeElite(e, affixes...)
+			enemies.SetSummons(e, se.Summons)
+			e.SetPos(se.X, se.Y)
+			e.SetHP(se.HP)
+			lvl.Enemies = append(lvl.Enemies, e)
+		}
+		for _, sn := range sl.Nests {
+			lvl.Nests = append(lvl.Nests, &Nest{
+				X: sn.X, Y: sn.Y, Enemy: sn.Enemy, Every: sn.Every, Cap: sn.Cap, timer: sn.Timer,
+				brood: pickEnemies(lvl.Enemies, sn.Brood),
+			})
+		}
+		for _, sa := range sl.Ambushes {
+			if sa.Room < 0 || sa.Room >= len(sl.Map.Rooms) {
+				return fmt.Errorf("load: level %d ambush in missing room %d", sl.Depth, sa.Room)
+			}
+			lvl.Ambushes = append(lvl.Ambushes, &Ambush{
+				Room: sa.Room, Count: sa.Count, Sprung: sa.Sprung, Gates: sa.Gates,
+				foes: pickEnemies(lvl.Enemies, sa.Foes),
+			})
+		}
+		levels = append(levels, lvl)
+	}
+
+	inv := inventory.New(max(w.Inv.Max, len(sf.Inventory)))
+	for _, s := range sf.Inventory {
+		it, err := w.restoreItem(s.ID, s.Loot)
+		if err != nil {
+			return err
+		}
+		// slots are restored as saved (not re-merged), so Equipped indices hold
+		inv.Slots = append(inv.Slots, inventory.Stack{Item: it, Qty: max(s.Qty, 1)})
+	}
+
+	var gear []items.Equipment
+	for _, i := range sf.Equipped {
+		eq, ok := inv.Get(i).(items.Equipment)
+		if !ok {
+			return fmt.Errorf("load: inventory slot %d is not equipment", i)
+		}
+		gear = append(gear, eq)
+	}
+
+	// Everything decoded: swap it in.
+	w.Seed = sf.Seed
+	w.Levels = levels
+	w.Level = levels[sf.Depth]
+	w.Inv = inv
+	w.InvSel = min(max(sf.InvSel, 0), max(inv.Count()-1, 0))
+	w.Waves = sf.Waves
+
+	p := w.Player
+	p.Attr = sf.Player.Attr
+	p.Effects = rpg.Effects{}
+	p.Gear = [player.NumSlots]player.Gear{}
+	for _, eq := range gear {
+		p.Equip(eq)
+	}
+	p.RecomputeStats()
+	// The saved Stats include whatever effects were active then; only the
+	// pools carry over, the caps come from Attr and Mods.
+	p.Stats.HP = min(sf.Player.Stats.HP, float64(p.Stats.HPMax))
+	p.Stats.MP = min(sf.Player.Stats.MP, float64(p.Stats.MPMax))
+	p.Stats.Stamina = min(sf.Player.Stats.Stamina, float64(p.Stats.StaminaMax))
+	p.Gold = sf.Player.Gold
+	p.SetPosPixels(sf.Player.X, sf.Player.Y)
+	w.updateFOV()
+	return nil
+}
+
+// lootSpec returns the rolled description of it, or nil for plain items.
+func lootSpec(it items.Item) *loot.Spec {
+	if l, ok := it.(*loot.Item); ok {
+		return &l.Spec
+	}
+	return nil
+}
+
+// restoreItem rebuilds a saved item: rolled loot from its spec, anything
+// else from the registry by id.
+func (w *World) restoreItem(id string, spec *loot.Spec) (items.Item, error) {
+	if spec != nil {
+		it, err := loot.Build(*spec)
+		if err != nil {
+			return nil, fmt.Errorf("load: %w", err)
+		}
+		return it, nil
+	}
+	it := items.New(id)
+	if it == nil {
+		return nil, fmt.Errorf("load: unknown item %q", id)
+	}
+	return it, nil
+}
+
+// savedIndices maps list to indices in the saved enemy list, leaving out
+// enemies that weren't saved (dead).
+func savedIndices(list []enemies.Enemy, saved map[enemies.Enemy]int) []int {
+	var out []int
+	for _, e := range list {
+		if i, ok := saved[e]; ok {
+			out = append(out, i)
+		}
+	}
+	return out
+}
+
+// pickEnemies is the inverse of savedIndices; bad indices are skipped.
+func pickEnemies(list []enemies.Enemy, idx []int) []enemies.Enemy {
+	var out []enemies.Enemy
+	for _, i := range idx {
+		if i >= 0 && i < len(list) {
+			out = append(out, list[i])
+		}
+	}
+	return out
+}
+
+// packBits stores bs as a bitset, eight tiles per byte.
+func packBits(bs []bool) []byte {
+	out := make([]byte, (len(bs)+7)/8)
+	for i, b := range bs {
+		if b {
+			out[i/8] |= 1 << (i % 8)
+		}
+	}
+	return out
+}
+
+// unpackBits fills dst from a packBits bitset; missing bits stay false.
+func unpackBits(src []byte, dst []bool) {
+	for i := range dst {
+		if i/8 < len(src) {
+			dst[i] = src[i/8]&(1<<(i%8)) != 0
+		}
+	}
+}
+
+// migrateSave checks the version and upgrades old saves to SaveVersion.
+func migrateSave(data []byte) ([]byte, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("load: %w", err)
+	}
+	var v int
+	if err := json.Unmarshal(raw["version"], &v); err != nil {
+		return nil, fmt.Errorf("load: missing save version")
+	}
+	if v > SaveVersion {
+		return nil, fmt.Errorf("load: save version %d is newer than this game (%d)", v, SaveVersion)
+	}
+	for ; v < SaveVersion; v++ {
+		m, ok := saveMigrations[v]
+		if !ok {
+			return nil, fmt.Errorf("load: no migration from save version %d", v)
+		}
+		if err := m(raw); err != nil {
+			return nil, fmt.Errorf("load: migrate v%d: %w", v, err)
+		}
+	}
+	raw["version"], _ = json.Marshal(SaveVersion)
+	return json.Marshal(raw)
+}
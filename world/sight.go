@@ -0,0 +1,36 @@
+package world
+
+import "example.com/go-quest/vision"
+
+// DefaultSightRadius is how far the player sees, in tiles.
+const DefaultSightRadius = 8
+
+// initSight allocates the per-tile explored/visible state for a floor.
+func (l *Level) initSight() {
+	l.Explored = make([]bool, len(l.Tiles))
+	l.Visible = make([]bool, len(l.Tiles))
+}
+
+// IsVisible reports whether tile (x,y) is in the player's current FOV.
+func (l *Level) IsVisible(x, y int) bool {
+	return l.InBounds(x, y) && l.Visible[l.idx(x, y)]
+}
+
+// IsExplored reports whether tile (x,y) has ever been seen on this floor.
+func (l *Level) IsExplored(x, y int) bool {
+	return l.InBounds(x, y) && l.Explored[l.idx(x, y)]
+}
+
+// updateFOV recomputes what the player sees from their tile and adds it
+// to the floor's explored map.
+func (w *World) updateFOV() {
+	clear(w.Visible)
+	ptx, pty := w.playerTile()
+	vision.FOV(ptx, pty, w.SightRadius, w.Opaque, func(x, y int) {
+		if w.InBounds(x, y) {
+			i := w.idx(x, y)
+			w.Visible[i] = true
+			w.Explored[i] = true
+		}
+	})
+}
@@ -0,0 +1,290 @@
+package world
+
+import (
+	"log"
+
+	"example.com/go-quest/enemies"
+	"example.com/go-quest/items"
+)
+
+func (w *World) spawnDemoNearPlayer() {
+	ptx := int((w.Player.X + TileSize/2) / TileSize)
+	pty := int((w.Player.Y + TileSize/2) / TileSize)
+
+	// exactly at player tile and one to the right
+	w.spawnItem("health_potion", ptx, pty)
+	w.spawnItem("boots_haste", ptx+1, pty)
+	log.Printf("spawned demo items at (%d,%d) and (%d,%d)", ptx, pty, ptx+1, pty)
+}
+
+func (w *World) placeRandomDoors(n int) {
+	placed := 0
+	for tries := 0; tries < 500 && placed < n; tries++ {
+		x := 1 + w.rng.IntN(w.W-2)
+		y := 1 + w.rng.IntN(w.H-2)
+		if w.At(x, y) != TFloor {
+			continue
+		}
+		// Optional: only place on corridor “choke points”
+		nFloor := 0
+		if w.At(x+1, y) == TFloor {
+			nFloor++
+		}
+		if w.At(x-1, y) == TFloor {
+			nFloor++
+		}
+		if w.At(x, y+1) == TFloor {
+			nFloor++
+		}
+		if w.At(x, y-1) == TFloor {
+			nFloor++
+		}
+		if nFloor < 2 {
+			continue
+		}
+
+		w.Set(x, y, TDoor)
+		placed++
+	}
+}
+
+func (w *World) paintWaterBlobs(count, radius int) {
+	for i := 0; i < count; i++ {
+		cx := 1 + w.rng.IntN(w.W-2)
+		cy := 1 + w.rng.IntN(w.H-2)
+		for y := cy - radius; y <= cy+radius; y++ {
+			for x := cx - radius; x <= cx+radius; x++ {
+				if !w.InBounds(x, y) {
+					continue
+				}
+				dx := x - cx
+				dy := y - cy
+				if dx*dx+dy*dy <= radius*radius {
+					if w.At(x, y) == TFloor {
+						w.Set(x, y, TWater)
+					}
+				}
+			}
+		}
+	}
+}
+
+// playerTile returns the tile the player's center is standing on.
+func (w *World) playerTile() (int, int) {
+	return int((w.Player.X + TileSize/2) / TileSize), int((w.Player.Y + TileSize/2) / TileSize)
+}
+
+// spawnItem creates a world item at tile (tx,ty) using the items registry.
+func (w *World) spawnItem(id string, tx, ty int) {
+	inst := items.New(id)
+	if inst == nil {
+		log.Printf("item id %q not registered", id)
+		return
+	}
+	w.placeItem(inst, tx, ty)
+}
+
+// placeItem puts this exact instance on the ground at (tx,ty), so rolled
+// loot keeps its affixes when dropped.
+func (w *World) placeItem(it items.Item, tx, ty int) {
+	w.ItemsOnGround = append(w.ItemsOnGround, WorldItem{
+		ID: it.ID(), X: tx, Y: ty, Inst: it,
+	})
+}
+
+// spawnGold creates a gold pile at a tile (tx, ty) with a given amount.
+func (w *World) spawnGold(val, tx, ty int) {
+	// Ensure it's a floor tile before placing
+	if w.At(tx, ty) != TFloor {
+		return
+	}
+
+	// Ensure tile is empty (no items or enemies)
+	if w.tileHasItemOrEnemy(tx, ty) {
+		return
+	}
+
+	w.ItemsOnGround = append(w.ItemsOnGround, WorldItem{
+		ID:  "gold",
+		X:   tx,
+		Y:   ty,
+		Val: val,
+	})
+}
+
+// eliteChance is the odds a randomly spawned enemy is an elite: 8% on the
+// first floor, +2% per floor, at most 25%.
+func eliteChance(depth int) float64 {
+	return min(0.08+0.02*float64(depth), 0.25)
+}
+
+// pick a random floor tile in a room (never the start room) and spawn enemies there.
+func (w *World) spawnEnemiesRandom(n int, allowedTypes []string) {
+	if n <= 0 {
+		return
+	}
+
+	candidates := w.roomFloorTiles(true)
+
+	if len(candidates) == 0 {
+		return
+	}
+
+	// fallback: if no allowedTypes provided, choose from registry
+	types := allowedTypes
+	if len(types) == 0 {
+		types = enemies.AllIDs()
+	}
+	if len(types) == 0 {
+		log.Println("no enemy types registered")
+		return
+	}
+
+	for i := 0; i < n && len(candidates) > 0; i++ {
+
+		// pick a candidate tile
+		idx := w.rng.IntN(len(candidates))
+		c := candidates[idx]
+
+		// pick random enemy type
+		et := types[w.rng.IntN(len(types))]
+		e := enemies.New(et)
+		if e == nil {
+			// if enemy type is not registered, skip
+			// but don’t crash
+			continue
+		}
+		e.ScaleLevel(w.Depth) // deeper floors → tougher monsters
+		if w.rng.Float64() < eliteChance(w.Depth) {
+			enemies.RollElite(e, w.rng, 1+w.rng.IntN(1+min(w.Depth/3, 2)))
+		}
+
+		// align enemy sprite with the tile (x,y is the top-left pixel, like the player)
+		px := float64(c.x * TileSize)
+		py := float64(c.y * TileSize)
+		e.SetPos(px, py)
+
+		w.Enemies = append(w.Enemies, e)
+
+		// remove tile from candidate list so we don’t spawn duplicates there
+		candidates[idx] = candidates[len(candidates)-1]
+		candidates = candidates[:len(candidates)-1]
+	}
+
+}
+
+// spawnGoldRandom scatters `count` gold piles across the dungeon.
+// minVal..maxVal defines the random amount per pile (inclusive).
+// It avoids walls, tiles that already hold an item/enemy, and tiles too close to the player.
+func (w *World) spawnGoldRandom(count, minVal, maxVal int) {
+	if count <= 0 || maxVal < minVal {
+		return
+	}
+
+	placed := 0
+	tries := 0
+	maxTries := count * 30 // safety cap to avoid infinite loops
+
+	px := int((w.Player.X + TileSize/2) / TileSize)
+	py := int((w.Player.Y + TileSize/2) / TileSize)
+
+	for placed < count && tries < maxTries {
+		tries++
+		// pick a random room, then a random tile inside it
+		x, y := w.randomRoomTile()
+
+		// must be walkable floor
+		if w.At(x, y) != TFloor {
+			continue
+		}
+		// avoid player vicinity (2 tiles radius)
+		if abs(x-px) <= 2 && abs(y-py) <= 2 {
+			continue
+		}
+		// avoid tiles that already have an item or enemy
+		if w.tileHasItemOrEnemy(x, y) {
+			continue
+		}
+
+		// pick value
+		val := minVal
+		if maxVal > minVal {
+			val = minVal + w.rng.IntN(maxVal-minVal+1)
+		}
+
+		w.ItemsOnGround = append(w.ItemsOnGround, WorldItem{
+			ID:   "gold",
+			X:    x,
+			Y:    y,
+			Inst: nil,
+			Val:  val,
+		})
+		placed++
+	}
+
+	// optional debug log
+	// log.Printf("spawnGoldRandom placed %d gold piles (%d tries)\n", placed, tries)
+}
+
+// roomFloorTiles lists free floor tiles inside the layout's rooms, in room order.
+// skipStart leaves out the player's start room so nothing spawns on top of them.
+func (w *World) roomFloorTiles(skipStart bool) []struct{ x, y int } {
+	px := int((w.Player.X + TileSize/2) / TileSize)
+	py := int((w.Player.Y + TileSize/2) / TileSize)
+
+	out := make([]struct{ x, y int }, 0, 1024)
+	for i, r := range w.Map.Rooms {
+		if skipStart && i == w.Map.Start {
+			continue
+		}
+		for y := r.Min.Y; y < r.Max.Y; y++ {
+			for x := r.Min.X; x < r.Max.X; x++ {
+				if w.At(x, y) != TFloor || (x == px && y == py) {
+					continue
+				}
+				// skip tiles that already hold an item or enemy
+				if w.tileHasItemOrEnemy(x, y) {
+					continue
+				}
+				out = append(out, struct{ x, y int }{x, y})
+			}
+		}
+	}
+	return out
+}
+
+// randomRoomTile picks a tile inside a random room (any tile if there are no rooms).
+func (w *World) randomRoomTile() (int, int) {
+	if len(w.Map.Rooms) == 0 {
+		return 1 + w.rng.IntN(w.W-2), 1 + w.rng.IntN(w.H-2)
+	}
+	r := w.Map.Rooms[w.rng.IntN(len(w.Map.Rooms))]
+	return r.Min.X + w.rng.IntN(r.Dx()), r.Min.Y + w.rng.IntN(r.Dy())
+}
+
+// helper: check if a tile already contains an item or enemy
+func (w *World) tileHasItemOrEnemy(tx, ty int) bool {
+	// item check
+	for _, it := range w.ItemsOnGround {
+		if it.X == tx && it.Y == ty {
+			return true
+		}
+	}
+	// enemy check: convert enemy pixel pos to tile coords
+	for _, e := range w.Enemies {
+		ex := int((e.X()) / TileSize)
+		ey := int((e.Y()) / TileSize)
+		if ex == tx && ey == ty {
+			return true
+		}
+	}
+	// you can also check player here, but spawnEnemiesRandom already avoids player tile
+	return false
+}
+
+func abs(a int) int {
+	if a < 0 {
+		return -a
+	}
+	return a
+}
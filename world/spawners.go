@@ -0,0 +1,181 @@
+package world
+
+import (
+	"image"
+
+	"example.com/go-quest/enemies"
+)
+
+// Nest is a spawner: while the player is nearby it hatches an enemy of
+// type Enemy on its tile every Every seconds, as long as fewer than Cap of
+// its brood are alive.
+type Nest struct {
+	X, Y  int // tile
+	Enemy string
+	Every float64
+	Cap   int
+
+	timer float64
+	brood []enemies.Enemy
+}
+
+// Ambush is a room that locks its doors and fills with Count enemies the
+// first time the player walks in. The doors reopen once they are all dead.
+type Ambush struct {
+	Room   int
+	Count  int
+	Sprung bool
+	Gates  []image.Point // entrances sealed while the fight is on
+
+	foes []enemies.Enemy
+}
+
+// Spawner tuning.
+const (
+	nestEvery = 10.0 // seconds between hatchings
+	nestCap   = 3    // living brood per nest
+	nestWake  = 12   // nests only hatch with the player this close (tiles)
+)
+
+// placeSpawners puts nests and an ambush in random rooms (never the start
+// room or the boss arena, and at most one of either per room).
+func (w *World) placeSpawners(nests, ambushes int) {
+	types := enemies.AllIDs()
+	if len(types) == 0 {
+		return
+	}
+	used := map[int]bool{w.Map.Start: true}
+	if a := w.Map.Arena; a != nil {
+		used[a.Room] = true
+	}
+	rooms := w.rng.Perm(len(w.Map.Rooms))
+
+	for _, i := range rooms {
+		if nests == 0 {
+			break
+		}
+		x, y := w.Map.RoomCenter(i)
+		if used[i] || w.At(x, y) != TFloor {
+			continue
+		}
+		used[i] = true
+		nests--
+		w.Nests = append(w.Nests, &Nest{
+			X: x, Y: y,
+			Enemy: types[w.rng.IntN(len(types))],
+			Every: nestEvery, Cap: nestCap,
+			timer: nestEvery,
+		})
+	}
+
+	for _, i := range rooms {
+		if ambushes == 0 {
+			break
+		}
+		// small rooms with few ways in make good traps
+		r := w.Map.Rooms[i]
+		if used[i] || r.Dx() < 5 || r.Dy() < 5 || len(w.Map.Entrances(i, walkable)) > 4 {
+			continue
+		}
+		used[i] = true
+		ambushes--
+		w.Ambushes = append(w.Ambushes, &Ambush{Room: i, Count: 3 + w.Depth/2})
+	}
+}
+
+// updateSpawners hatches nests and springs or clears ambushes.
+func (w *World) updateSpawners(dt float64) {
+	ptx, pty := w.playerTile()
+	for _, n := range w.Nests {
+		n.brood = living(n.brood)
+		if abs(n.X-ptx) > nestWake || abs(n.Y-pty) > nestWake || len(n.brood) >= n.Cap {
+			continue
+		}
+		n.timer -= dt
+		if n.timer > 0 {
+			continue
+		}
+		n.timer = n.Every
+		if e := w.spawnAt(n.Enemy, n.X, n.Y); e != nil {
+			n.brood = append(n.brood, e)
+		}
+	}
+
+	for _, a := range w.Ambushes {
+		switch {
+		case !a.Sprung && w.inRoom(a.Room):
+			a.Sprung = true
+			a.Gates = w.seal(a.Room)
+			a.foes = w.spawnInRoom(a.Room, a.Count)
+			w.say("It's an ambush!", 2.0)
+		case len(a.Gates) > 0:
+			if a.foes = living(a.foes); len(a.foes) == 0 {
+				w.unseal(a.Gates)
+				a.Gates = nil
+				w.say("The doors creak open.", 2.0)
+			}
+		}
+	}
+}
+
+// spawnInRoom places n random enemies on free floor in room i, keeping
+// them a couple of tiles off the player where the room allows.
+func (w *World) spawnInRoom(room, n int) []enemies.Enemy {
+	types := enemies.AllIDs()
+	if len(types) == 0 {
+		return nil
+	}
+	ptx, pty := w.playerTile()
+	var near, far []image.Point
+	r := w.Map.Rooms[room]
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			if w.At(x, y) != TFloor || (x == ptx && y == pty) {
+				continue
+			}
+			if abs(x-ptx) <= 2 && abs(y-pty) <= 2 {
+				near = append(near, image.Pt(x, y))
+			} else {
+				far = append(far, image.Pt(x, y))
+			}
+		}
+	}
+	w.rng.Shuffle(len(far), func(i, j int) { far[i], far[j] = far[j], far[i] })
+	w.rng.Shuffle(len(near), func(i, j int) { near[i], near[j] = near[j], near[i] })
+	spots := append(far, near...)
+
+	var out []enemies.Enemy
+	for i := 0; i < n && i < len(spots); i++ {
+		if e := w.spawnAt(types[w.rng.IntN(len(types))], spots[i].X, spots[i].Y); e != nil {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// spawnAt adds an enemy of type id on tile (tx,ty), scaled to the floor
+// and sometimes an elite, like the enemies a floor starts with.
+func (w *World) spawnAt(id string, tx, ty int) enemies.Enemy {
+	e := enemies.New(id)
+	if e == nil {
+		return nil
+	}
+	e.ScaleLevel(w.Depth)
+	if w.rng.Float64() < eliteChance(w.Depth) {
+		enemies.RollElite(e, w.rng, 1)
+	}
+	e.SetPos(float64(tx*TileSize), float64(ty*TileSize))
+	w.Enemies = append(w.Enemies, e)
+	return e
+}
+
+// living filters list down to the enemies still alive, in place.
+func living(list []enemies.Enemy) []enemies.Enemy {
+	out := list[:0]
+	for _, e := range list {
+		if e.IsAlive() {
+			out = append(out, e)
+		}
+	}
+	return out
+}
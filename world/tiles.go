@@ -0,0 +1,34 @@
+package world
+
+// Tile IDs — kept small and centralised
+const (
+	TEmpty = iota
+	TFloor
+	TWall
+	TDoor
+	TWater
+	TStairsDown
+	TStairsUp
+)
+
+// TileSize is the edge of one tile in world pixels.
+const TileSize = 32
+
+// Helpers for tile indexing and bounds (on the current floor)
+func (l *Level) idx(x, y int) int       { return y*l.W + x }
+func (l *Level) InBounds(x, y int) bool { return x >= 0 && y >= 0 && x < l.W && y < l.H }
+func (l *Level) At(x, y int) int        { return l.Tiles[l.idx(x, y)] }
+func (l *Level) Set(x, y, v int)        { l.Tiles[l.idx(x, y)] = v }
+
+// walkable reports whether a tile id can be stood on (walls and water block).
+func walkable(t int) bool { return t != TWall && t != TWater }
+
+// Opaque reports whether a tile blocks line of sight (walls and off-map).
+func (l *Level) Opaque(tx, ty int) bool {
+	return !l.InBounds(tx, ty) || l.At(tx, ty) == TWall
+}
+
+// Passable is the tile-coord callback handed to the player and enemies.
+func (l *Level) Passable(tx, ty int) bool {
+	return l.InBounds(tx, ty) && walkable(l.At(tx, ty))
+}
@@ -0,0 +1,90 @@
+package world
+
+import (
+	"fmt"
+	"image"
+
+	"example.com/go-quest/dungeon"
+	"example.com/go-quest/enemies"
+)
+
+// Waves is arena mode: the run is a single open pit, and enemies come in
+// waves that grow in number and level. The next wave starts Pause seconds
+// after the floor has been cleared.
+type Waves struct {
+	Wave  int     `json:"wave"`  // waves started so far
+	Pause float64 `json:"pause"` // seconds until the next wave (while the floor is clear)
+}
+
+// wavePause is the breather between waves.
+const wavePause = 5.0
+
+// StartWaves switches the run to arena mode: every floor is replaced by a
+// pit (dungeon.Pit) with the player in the middle, and waves begin.
+// The player keeps their gear, level and inventory.
+func (w *World) StartWaves() {
+	lvl := w.newLevel(0, dungeon.Pit{})
+	w.Levels = []*Level{lvl}
+	w.Level = lvl
+	x, y := lvl.Map.RoomCenter(0)
+	w.Player.SetPosPixels(float64(x*TileSize), float64(y*TileSize))
+	w.Waves = &Waves{Pause: wavePause}
+	w.say("Survive the waves!", 2.0)
+	w.updateFOV()
+}
+
+// updateWaves counts down once the pit is empty and sends the next wave.
+func (w *World) updateWaves(dt float64) {
+	if w.Waves == nil {
+		return
+	}
+	for _, e := range w.Enemies {
+		if e.IsAlive() {
+			return // wave still in progress
+		}
+	}
+	w.Waves.Pause -= dt
+	if w.Waves.Pause > 0 {
+		return
+	}
+	w.Waves.Wave++
+	w.Waves.Pause = wavePause
+	w.spawnWave(w.Waves.Wave)
+	w.say(fmt.Sprintf("Wave %d", w.Waves.Wave), 2.0)
+}
+
+// spawnWave sends wave n (from 1): 2+2n enemies, one level tougher every
+// other wave, with elites from the third wave on. They come in along the
+// pit walls, away from the player.
+func (w *World) spawnWave(n int) {
+	types := enemies.AllIDs()
+	if len(types) == 0 {
+		return
+	}
+	ptx, pty := w.playerTile()
+	r := w.Map.Rooms[0]
+	var spots []image.Point
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			edge := x == r.Min.X || y == r.Min.Y || x == r.Max.X-1 || y == r.Max.Y-1
+			if edge && w.At(x, y) == TFloor && (abs(x-ptx) > 3 || abs(y-pty) > 3) {
+				spots = append(spots, image.Pt(x, y))
+			}
+		}
+	}
+	w.rng.Shuffle(len(spots), func(i, j int) { spots[i], spots[j] = spots[j], spots[i] })
+
+	for i := 0; i < 2+2*n && len(spots) > 0; i++ {
+		p := spots[i%len(spots)]
+		e := enemies.New(types[w.rng.IntN(len(types))])
+		if e == nil {
+			continue
+		}
+		e.ScaleLevel(n / 2)
+		if n >= 3 && w.rng.Float64() < eliteChance(n) {
+			enemies.RollElite(e, w.rng, 1+w.rng.IntN(1+min(n/4, 2)))
+		}
+		e.SetPos(float64(p.X*TileSize), float64(p.Y*TileSize))
+		w.Enemies = append(w.Enemies, e)
+	}
+}
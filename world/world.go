@@ -0,0 +1,325 @@
+package world
+
+import (
+	"fmt"
+	"math"
+
+	"example.com/go-quest/enemies"
+	"example.com/go-quest/inventory"
+	"example.com/go-quest/items"
+	"example.com/go-quest/move"
+	"example.com/go-quest/player"
+	"example.com/go-quest/rpg"
+)
+
+// WorldItem is something lying on the floor of a level.
+type WorldItem struct {
+	ID   string
+	X, Y int // tile coords
+	Inst items.Item
+	Val  int // gold amount for ID == "gold"
+}
+
+// World is the headless simulation: floors, player, inventory and combat.
+// It never reads the keyboard or draws; the caller feeds it an Input and a
+// dt each Step, which makes runs reproducible and testable without a display.
+type World struct {
+	// Current floor (tiles, enemies, ground items). Fields are promoted, so
+	// w.Tiles, w.Enemies and w.ItemsOnGround always mean "this floor".
+	*Level
+	Levels []*Level // every floor generated so far, indexed by depth
+
+	// Seed fully determines a run: each floor derives its own RNG from it.
+	Seed uint64
+
+	Player *player.Player
+
+	// SightRadius is the player's FOV radius in tiles.
+	SightRadius int
+
+	// Waves is arena mode's state (nil = a normal dungeon run; see StartWaves)
+	Waves *Waves
+
+	// Inventory
+	Inv    *inventory.Inventory
+	InvSel int // selected inventory slot for use/drop (0..)
+
+	// Tooltip is a short message for the UI ("+5 Gold", "Press [E] ...")
+	Tooltip      string
+	TooltipTimer float64
+}
+
+// New builds the first floor and places the player in its start room.
+// The seed drives every random choice, so the same seed rebuilds the same run.
+func New(seed uint64) *World {
+	w := &World{Seed: seed, SightRadius: DefaultSightRadius}
+
+	w.Player = player.New() // default speed is set in player.New()
+
+	w.Player.AddEffect(rpg.Haste(1.10, 60)) // Minor Haste buff (+10%) for a minute
+
+	// Make the first floor; this also places the player in its start room.
+	w.buildLevel(0)
+
+	// --- Inventory + ground items ---
+	w.Inv = inventory.New(12) // 12-slot bag
+
+	// Starting kit: a sword in hand
+	if sword, ok := items.New("short_sword").(items.Equipment); ok && w.Inv.Add(sword) {
+		w.Player.Equip(sword)
+	}
+
+	w.spawnDemoNearPlayer()
+
+	// Spawn a couple of demo items on the ground (change coords to somewhere reachable)
+	w.spawnItem("health_potion", 10, 10)
+	w.spawnItem("boots_haste", 14, 12)
+
+	// Enemies (slime and goblin come from data files; skip any that didn't load)
+	ptx, pty := w.playerTile()
+	if e1 := enemies.New("slime"); e1 != nil {
+		e1.SetPos(float64((ptx+3)*TileSize), float64(pty*TileSize))
+		w.Enemies = append(w.Enemies, e1)
+	}
+	if e2 := enemies.New("goblin"); e2 != nil {
+		e2.SetPos(float64((ptx+6)*TileSize), float64(pty*TileSize))
+		w.Enemies = append(w.Enemies, e2)
+	}
+
+	w.updateFOV()
+
+	return w
+}
+
+// Step advances the simulation by dt seconds using the given input.
+func (w *World) Step(in Input, dt float64) {
+	// player movement + collision via callback
+	w.Player.Update(dt, TileSize, in.MoveX, in.MoveY, w.Passable)
+
+	// --- Player Attack ---
+	didAttack := false
+	if in.Attack && w.Player.CanAttack() {
+		didAttack = true
+		w.Player.DoAttack()
+	}
+
+	// Update enemies
+	env := &enemies.Env{
+		PX: w.Player.X, PY: w.Player.Y,
+		Passable: w.Passable, Opaque: w.Opaque,
+		Fire: w.fire, Summon: w.summon,
+		Say: func(msg string) { w.say(msg, 2.5) },
+	}
+	for i := 0; i < len(w.Enemies); i++ {
+		ee := w.Enemies[i]
+		if !ee.IsAlive() {
+			// death effects, the player's reward, then remove the dead enemy
+			ee.OnDeath(env)
+			w.onKill(ee)
+			w.Enemies = append(w.Enemies[:i], w.Enemies[i+1:]...)
+			i--
+			continue
+		}
+
+		// update AI
+		ee.Update(dt, env)
+
+		// enemy → player attacks
+		if ee.AttackIfInRange(w.Player.X, w.Player.Y) {
+			hit := rpg.ResolveHit(ee.Stats(), w.Player.Stats, rpg.Physical, w.rng)
+			w.Player.TakeDamage(hit.Damage)
+			ee.DealtDamage(hit.Damage)
+			for _, fx := range ee.HitEffects() {
+				w.Player.AddEffect(fx)
+			}
+		}
+
+		// player → enemy attack
+		if didAttack {
+			// distance from player to enemy
+			dx := (ee.X() - w.Player.X)
+			dy := (ee.Y() - w.Player.Y)
+			dist := math.Hypot(dx, dy)
+
+			if dist <= w.Player.AttackRangePx() {
+				// deal damage
+				hit := rpg.ResolveHit(w.Player.Stats, ee.Stats(), rpg.Physical, w.rng)
+				ee.TakeDamage(hit.Damage)
+				if hit.Crit {
+					w.say(fmt.Sprintf("Critical hit! %s", hit), 0.8)
+				}
+
+				// optional: add a knockback or flash here
+			}
+		}
+	}
+
+	// shots in flight (fired above or on earlier steps)
+	w.updateProjectiles(dt)
+
+	// spread crowds out so they don't stack on one pixel
+	enemies.Separate(w.Enemies, w.Player.X, w.Player.Y, dt, w.Passable)
+
+	// boss arena: shut the doors behind the player, reopen them after the kill
+	w.updateArena()
+
+	// nests, ambushes and arena-mode waves
+	w.updateSpawners(dt)
+	w.updateWaves(dt)
+
+	// --- Inventory interactions ---
+
+	// tile player is standing on
+	ptx, pty := w.playerTile()
+
+	// 1) Gold is picked up automatically
+	for i := 0; i < len(w.ItemsOnGround); i++ {
+		wi := w.ItemsOnGround[i]
+		if wi.ID == "gold" && wi.X == ptx && wi.Y == pty {
+			w.Player.Gold += wi.Val
+			w.say(fmt.Sprintf("+%d Gold", wi.Val), 1.0)
+			w.ItemsOnGround = append(w.ItemsOnGround[:i], w.ItemsOnGround[i+1:]...)
+			i--
+		}
+	}
+
+	// 2) Other items: pick up when standing on them and Pickup is pressed
+	standingOnItem := false
+	for i := range w.ItemsOnGround {
+		wi := w.ItemsOnGround[i]
+		if wi.X == ptx && wi.Y == pty {
+			standingOnItem = true
+			w.say(fmt.Sprintf("Press [E] to pick up %s", wi.Inst.Name()), 1.0) // seconds visible after stepping off
+			if in.Pickup {
+				if w.Inv.Add(wi.Inst) {
+					wi.Inst.OnPickup(w.Player)
+					// remove from ground
+					w.ItemsOnGround = append(w.ItemsOnGround[:i], w.ItemsOnGround[i+1:]...)
+				}
+			}
+			break
+		}
+	}
+	if !standingOnItem && w.TooltipTimer > 0 {
+		w.TooltipTimer -= dt
+		if w.TooltipTimer < 0 {
+			w.TooltipTimer = 0
+			w.Tooltip = ""
+		}
+	}
+
+	// 3) Cycle selected slot
+	if in.PrevSlot && w.InvSel > 0 {
+		w.InvSel--
+	}
+	if in.NextSlot && w.InvSel < w.Inv.Count()-1 {
+		w.InvSel++
+	}
+
+	// 4) Use selected item
+	if in.Use {
+		it := w.Inv.Get(w.InvSel)
+		if it != nil && it.OnUse(w.Player) { // consumed? (one from the stack)
+			w.Inv.TakeOne(w.InvSel)
+			w.clampInvSel()
+		} else if eq, ok := it.(items.Equipment); ok {
+			verb := "Unequipped"
+			if w.Player.IsEquipped(eq) {
+				verb = "Equipped"
+			}
+			w.say(fmt.Sprintf("%s %s", verb, eq.Name()), 1.0)
+		}
+	}
+
+	// 5) Drop selected item
+	if in.Drop {
+		it := w.Inv.Get(w.InvSel)
+		if it != nil && it.OnDrop(w.Player, ptx, pty) {
+			w.placeItem(w.droppedOne(w.InvSel), ptx, pty)
+			w.Inv.TakeOne(w.InvSel)
+			w.clampInvSel()
+		}
+	}
+
+	// 6) Split the selected stack in half / merge it into a matching stack
+	if in.Split {
+		w.Inv.Split(w.InvSel, w.Inv.Qty(w.InvSel)/2)
+	}
+	if in.Merge {
+		for i := range w.Inv.Slots {
+			if i != w.InvSel && w.Inv.Merge(w.InvSel, i) {
+				w.clampInvSel()
+				break
+			}
+		}
+	}
+
+	// 7) Stairs
+	switch w.At(ptx, pty) {
+	case TStairsDown:
+		w.say("Press [.] to go down", 1.0)
+		if in.Descend {
+			w.changeLevel(w.Depth + 1)
+		}
+	case TStairsUp:
+		w.say("Press [,] to go up", 1.0)
+		if in.Ascend {
+			w.changeLevel(w.Depth - 1)
+		}
+	}
+
+	// 8) Attribute points from the character screen
+	if in.Spend != rpg.AttrNone {
+		w.Player.SpendPoint(in.Spend)
+	}
+
+	// 9) What the player can see from where they ended up
+	w.updateFOV()
+}
+
+// onKill rewards the player for defeating e: XP, plus its loot table
+// spilled around the corpse.
+func (w *World) onKill(e enemies.Enemy) {
+	tx, ty := move.Tile(e.X(), e.Y(), TileSize)
+	w.dropLoot(e.LootTable(), tx, ty)
+	if len(enemies.Elite(e)) > 0 {
+		w.dropLoot("elite", tx, ty) // champions carry extra loot
+	}
+
+	xp := e.XP()
+	if lv := w.Player.GainXP(xp); lv > 0 {
+		w.say(fmt.Sprintf("Level up! Level %d - press [C] to spend points", w.Player.Attr.Level), 2.5)
+		return
+	}
+	w.say(fmt.Sprintf("+%d XP", xp), 1.0)
+}
+
+// droppedOne returns the instance to put on the ground when one item is
+// dropped from inventory slot i: the item itself if it's the last of its
+// stack, otherwise a fresh copy, so the ground and the bag never share one.
+func (w *World) droppedOne(i int) items.Item {
+	it := w.Inv.Get(i)
+	if w.Inv.Qty(i) <= 1 {
+		return it
+	}
+	if c, err := w.restoreItem(it.ID(), lootSpec(it)); err == nil {
+		return c
+	}
+	return it
+}
+
+// say shows a tooltip message for `secs` seconds.
+func (w *World) say(msg string, secs float64) {
+	w.Tooltip = msg
+	w.TooltipTimer = secs
+}
+
+// clampInvSel keeps the selection on a valid slot after removals.
+func (w *World) clampInvSel() {
+	if w.InvSel >= w.Inv.Count() {
+		w.InvSel = w.Inv.Count() - 1
+	}
+	if w.InvSel < 0 {
+		w.InvSel = 0
+	}
+}
@@ -0,0 +1,309 @@
+package world
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"example.com/go-quest/enemies"
+	"example.com/go-quest/inventory"
+	"example.com/go-quest/items"
+	"example.com/go-quest/player"
+	"example.com/go-quest/rpg"
+)
+
+const (
+	testSeed = 12345
+	dt       = 1.0 / 60.0
+)
+
+func TestMain(m *testing.M) {
+	// slime and goblin are defined in data files
+	if err := enemies.LoadDir("../assets/enemies"); err != nil {
+		panic(err)
+	}
+	os.Exit(m.Run())
+}
+
+// newTestWorld builds a seeded world and clears it down to the player in
+// a closed 9x9 floor room (walls x,y = 1..11, floor 2..10), standing on
+// tile (6,6), with nothing else on the floor.
+func newTestWorld(t *testing.T) *World {
+	t.Helper()
+	w := New(testSeed)
+	w.Enemies = nil
+	w.ItemsOnGround = nil
+	w.Projectiles = nil
+	w.Nests = nil
+	w.Ambushes = nil
+	for y := 1; y <= 11; y++ {
+		for x := 1; x <= 11; x++ {
+			tile := TFloor
+			if x == 1 || y == 1 || x == 11 || y == 11 {
+				tile = TWall
+			}
+			w.Set(x, y, tile)
+		}
+	}
+	w.Player.SetPosPixels(6*TileSize, 6*TileSize)
+	return w
+}
+
+// addEnemy puts a fresh enemy of type id at pixel (x,y).
+func addEnemy(t *testing.T, w *World, id string, x, y float64) enemies.Enemy {
+	t.Helper()
+	e := enemies.New(id)
+	if e == nil {
+		t.Fatalf("enemy %q not registered", id)
+	}
+	e.SetPos(x, y)
+	w.Enemies = append(w.Enemies, e)
+	return e
+}
+
+func TestSameSeedSameRun(t *testing.T) {
+	a, b := New(testSeed), New(testSeed)
+	in := Input{MoveX: 1, MoveY: 0.5, Attack: true}
+	for i := 0; i < 300; i++ {
+		a.Step(in, dt)
+		b.Step(in, dt)
+	}
+	if a.Player.X != b.Player.X || a.Player.Y != b.Player.Y || a.Player.Stats.HP != b.Player.Stats.HP {
+		t.Fatalf("players diverged: (%v,%v) %v HP vs (%v,%v) %v HP",
+			a.Player.X, a.Player.Y, a.Player.Stats.HP, b.Player.X, b.Player.Y, b.Player.Stats.HP)
+	}
+	if len(a.Enemies) != len(b.Enemies) {
+		t.Fatalf("enemy counts diverged: %d vs %d", len(a.Enemies), len(b.Enemies))
+	}
+	for i := range a.Enemies {
+		ea, eb := a.Enemies[i], b.Enemies[i]
+		if ea.X() != eb.X() || ea.Y() != eb.Y() || ea.Stats().HP != eb.Stats().HP {
+			t.Fatalf("enemy %d diverged", i)
+		}
+	}
+}
+
+func TestCombat(t *testing.T) {
+	w := newTestWorld(t)
+	e := addEnemy(t, w, "slime", w.Player.X+16, w.Player.Y)
+	hp, full := w.Player.Stats.HP, e.Stats().HP
+
+	// one swing hurts the slime, and the slime bites back
+	w.Step(Input{Attack: true}, dt)
+	if got := e.Stats().HP; got >= full {
+		t.Fatalf("slime HP after a hit = %v, want < %v", got, full)
+	}
+	if got := w.Player.Stats.HP; got >= hp {
+		t.Fatalf("player HP after a bite = %v, want < %v", got, hp)
+	}
+
+	// keep swinging until it dies; the kill removes it and pays XP
+	xp := w.Player.Attr.XP
+	for i := 0; i < 60*30 && len(w.Enemies) > 0; i++ {
+		w.Player.Stats.HP = float64(w.Player.Stats.HPMax) // outlast it
+		w.Step(Input{Attack: true}, dt)
+	}
+	if e.IsAlive() || len(w.Enemies) != 0 {
+		t.Fatalf("slime still up after 30s of swings (HP %v)", e.Stats().HP)
+	}
+	if w.Player.Attr.XP == xp && w.Player.Attr.Level == 1 {
+		t.Fatalf("no XP for the kill")
+	}
+}
+
+func TestOutOfReachNoDamage(t *testing.T) {
+	w := newTestWorld(t)
+	e := addEnemy(t, w, "slime", w.Player.X+3*TileSize, w.Player.Y)
+	full := e.Stats().HP
+	w.Step(Input{Attack: true}, dt)
+	if got := e.Stats().HP; got != full {
+		t.Fatalf("slime three tiles away took damage: HP %v, want %v", got, full)
+	}
+}
+
+func TestPickup(t *testing.T) {
+	w := newTestWorld(t)
+	ptx, pty := w.playerTile()
+	w.spawnItem("health_potion", ptx, pty)
+	n := w.Inv.Count()
+
+	// standing on it isn't enough
+	w.Step(Input{}, dt)
+	if len(w.ItemsOnGround) != 1 || w.Inv.Count() != n {
+		t.Fatalf("item picked up without pressing pickup")
+	}
+
+	w.Step(Input{Pickup: true}, dt)
+	if len(w.ItemsOnGround) != 0 {
+		t.Fatalf("item still on the ground after pickup")
+	}
+	if w.Inv.Count() != n+1 || w.Inv.Get(n).ID() != "health_potion" {
+		t.Fatalf("health potion not in the inventory")
+	}
+}
+
+func TestGoldPickup(t *testing.T) {
+	w := newTestWorld(t)
+	ptx, pty := w.playerTile()
+	w.spawnGold(25, ptx, pty)
+	w.Step(Input{}, dt)
+	if w.Player.Gold != 25 || len(w.ItemsOnGround) != 0 {
+		t.Fatalf("gold = %d with %d items on the ground, want 25 and none", w.Player.Gold, len(w.ItemsOnGround))
+	}
+}
+
+func TestWallsBlockMovement(t *testing.T) {
+	w := newTestWorld(t)
+	x0 := w.Player.X
+
+	// walk east into the wall at x = 11 for a few seconds
+	for i := 0; i < 60*4; i++ {
+		w.Step(Input{MoveX: 1}, dt)
+	}
+	if w.Player.X <= x0 {
+		t.Fatalf("player didn't move: x = %v", w.Player.X)
+	}
+	// the player's center (x + 16) stops short of the wall tile
+	if limit := float64(11*TileSize - TileSize/2); w.Player.X >= limit {
+		t.Fatalf("player walked into the wall: x = %v, want < %v", w.Player.X, limit)
+	}
+	if ptx, _ := w.playerTile(); !w.Passable(ptx, 6) {
+		t.Fatalf("player ended on a blocked tile (%d,6)", ptx)
+	}
+}
+
+func TestLoadOldSave(t *testing.T) {
+	w := newTestWorld(t)
+	w.Inv = inventory.New(12) // v1-v3 bags held bare ids; an empty one reads either way
+	path := t.TempDir() + "/save.json"
+	if err := w.Save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	// a v1 save upgrades cleanly through every migration...
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	old := strings.Replace(string(data), fmt.Sprintf(`"version": %d`, SaveVersion), `"version": 1`, 1)
+	if err := os.WriteFile(path, []byte(old), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Load(path); err != nil {
+		t.Fatalf("loading a v1 save: %v", err)
+	}
+
+	// ...and a save from a newer build is refused
+	newer := strings.Replace(old, `"version": 1`, fmt.Sprintf(`"version": %d`, SaveVersion+1), 1)
+	if err := os.WriteFile(path, []byte(newer), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Load(path); err == nil {
+		t.Fatalf("loaded a save from a newer version")
+	}
+}
+
+func TestLoadDropsTimedEffects(t *testing.T) {
+	w := newTestWorld(t)
+	p := w.Player
+	hasted := p.Stats.MoveSpeed // New starts the player with a minute of haste
+	p.Stats.HP = 10
+
+	path := t.TempDir() + "/save.json"
+	if err := w.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Load(path); err != nil {
+		t.Fatal(err)
+	}
+
+	base := rpg.Recompute(p.Attr, p.Mods...)
+	if p.Stats.MoveSpeed != base.MoveSpeed || base.MoveSpeed >= hasted {
+		t.Fatalf("move speed after load = %v, want the unhasted %v (was %v)", p.Stats.MoveSpeed, base.MoveSpeed, hasted)
+	}
+	if p.Stats.HP != 10 {
+		t.Fatalf("HP after load = %v, want 10", p.Stats.HP)
+	}
+}
+
+// token is a stackable test item with per-instance state.
+type token struct{ n int }
+
+func (t *token) ID() string                               { return "test_token" }
+func (t *token) Name() string                             { return "Token" }
+func (t *token) Icon() string                             { return "" }
+func (t *token) MaxStack() int                            { return 5 }
+func (t *token) OnPickup(p *player.Player)                {}
+func (t *token) OnUse(p *player.Player) bool              { return false }
+func (t *token) OnDrop(p *player.Player, wx, wy int) bool { return true }
+
+func init() {
+	items.Register("test_token", func() items.Item { return &token{} })
+}
+
+func TestDropFromStack(t *testing.T) {
+	w := newTestWorld(t)
+	ptx, pty := w.playerTile()
+	for i := 0; i < 3; i++ {
+		w.spawnItem("test_token", ptx, pty)
+		w.Step(Input{Pickup: true}, dt)
+	}
+	w.InvSel = w.Inv.Count() - 1
+	if w.Inv.Qty(w.InvSel) != 3 {
+		t.Fatalf("tokens didn't stack: qty %d", w.Inv.Qty(w.InvSel))
+	}
+
+	w.Step(Input{Drop: true}, dt)
+	if w.Inv.Qty(w.InvSel) != 2 || len(w.ItemsOnGround) != 1 {
+		t.Fatalf("after a drop: qty %d, %d on the ground; want 2 and 1", w.Inv.Qty(w.InvSel), len(w.ItemsOnGround))
+	}
+	if w.ItemsOnGround[0].Inst == w.Inv.Get(w.InvSel) {
+		t.Fatalf("dropped token is the same instance as the stack in the bag")
+	}
+}
+
+func TestBossGuardsStairs(t *testing.T) {
+	w := New(testSeed)
+	for d := 1; d < bossEvery; d++ {
+		w.changeLevel(d)
+	}
+	boss := w.Boss()
+	if w.Map.Arena == nil || boss == nil {
+		t.Fatalf("floor %d has no boss arena", w.Depth+1)
+	}
+	if w.At(w.DownX, w.DownY) == TStairsDown {
+		t.Fatalf("down stairs showing while the boss lives")
+	}
+
+	// the stairs appear once the boss is dead
+	w.Player.SetPosPixels(float64(w.UpX*TileSize), float64(w.UpY*TileSize))
+	boss.SetHP(0)
+	w.Step(Input{}, dt)
+	if w.At(w.DownX, w.DownY) != TStairsDown {
+		t.Fatalf("no down stairs after the boss died")
+	}
+}
+
+func TestSaveKeepsSummonCount(t *testing.T) {
+	w := newTestWorld(t)
+	e := addEnemy(t, w, "goblin", w.Player.X+5*TileSize, w.Player.Y)
+	if !enemies.MakeElite(e, enemies.Summoner) {
+		t.Fatalf("goblin can't be an elite")
+	}
+	enemies.SetSummons(e, 2)
+
+	path := t.TempDir() + "/save.json"
+	if err := w.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Load(path); err != nil {
+		t.Fatal(err)
+	}
+	if len(w.Enemies) != 1 {
+		t.Fatalf("%d enemies after load, want 1", len(w.Enemies))
+	}
+	if n := enemies.Summons(w.Enemies[0]); n != 2 {
+		t.Fatalf("summons after load = %d, want 2", n)
+	}
+}